@@ -14,11 +14,14 @@ import (
 const ARG_COUNT int = 2
 
 // Usage strings
-const USAGE_STRING = "./run-client.sh -d -f config_file.txt baseDir blockSize"
+const USAGE_STRING = "./run-client.sh -d -replica -f config_file.txt baseDir blockSize"
 
 const DEBUG_NAME = "d"
 const DEBUG_USAGE = "Output log statements"
 
+const REPLICA_NAME = "replica"
+const REPLICA_USAGE = "Read file metadata from any Raft node, possibly slightly stale"
+
 const CONFIG_NAME = "f config_file.txt"
 const CONFIG_USAGE = "Path to config file that specifies addresses for all Raft nodes"
 
@@ -37,6 +40,7 @@ func main() {
 		w := flag.CommandLine.Output()
 		fmt.Fprintf(w, "Usage of %s:\n", USAGE_STRING)
 		fmt.Fprintf(w, "  -%s: %v\n", DEBUG_NAME, DEBUG_USAGE)
+		fmt.Fprintf(w, "  -%s: %v\n", REPLICA_NAME, REPLICA_USAGE)
 		fmt.Fprintf(w, "  -%s: %v\n", CONFIG_NAME, CONFIG_USAGE)
 		fmt.Fprintf(w, "  %s: %v\n", BASEDIR_NAME, BASEDIR_USAGE)
 		fmt.Fprintf(w, "  %s: %v\n", BLOCK_NAME, BLOCK_USAGE)
@@ -44,6 +48,7 @@ func main() {
 
 	// Parse command-line arguments and flags
 	debug := flag.Bool("d", false, DEBUG_USAGE)
+	replicaReads := flag.Bool(REPLICA_NAME, false, REPLICA_USAGE)
 	configFile := flag.String("f", "", "(required) Config file")
 	flag.Parse()
 
@@ -72,5 +77,7 @@ func main() {
 	}
 
 	rpcClient := syncinator.NewSyncinatorRPCClient(addrs.RaftAddrs, baseDir, blockSize)
+	rpcClient.ReplicaReads = *replicaReads
+	defer rpcClient.Close()
 	syncinator.ClientSync(rpcClient)
 }
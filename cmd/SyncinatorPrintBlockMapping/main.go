@@ -70,6 +70,7 @@ func main() {
 	}
 
 	rpcClient := syncinator.NewSyncinatorRPCClient(addrs.RaftAddrs, baseDir, blockSize)
+	defer rpcClient.Close()
 	PrintBlocksOnEachServer(rpcClient)
 }
 
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"cse224/proj5/pkg/syncinator"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Usage strings
+const USAGE_STRING = "./SyncinatorRaftAdmin -d -f config_file.txt (add serverId addr | learner serverId addr | promote serverId | remove serverId | transfer serverId)"
+
+const DEBUG_NAME = "d"
+const DEBUG_USAGE = "Output log statements"
+
+const CONFIG_NAME = "f config_file.txt"
+const CONFIG_USAGE = "Path to config file that specifies addresses for all Raft nodes"
+
+const ADD_NAME = "add serverId addr"
+const ADD_USAGE = "Add the server listening on addr to the cluster, as a learner until it has caught up"
+
+const LEARNER_NAME = "learner serverId addr"
+const LEARNER_USAGE = "Add the server listening on addr to the cluster as a non-voting learner"
+
+const PROMOTE_NAME = "promote serverId"
+const PROMOTE_USAGE = "Make the learner a voter once it has caught up"
+
+const REMOVE_NAME = "remove serverId"
+const REMOVE_USAGE = "Remove the server from the cluster"
+
+const TRANSFER_NAME = "transfer serverId"
+const TRANSFER_USAGE = "Hand over leadership to the server, e.g. before restarting the leader"
+
+// Exit codes
+const EX_USAGE int = 64
+
+const ADMIN_TIMEOUT = 10 * time.Second
+
+func main() {
+	// Custom flag Usage message
+	flag.Usage = func() {
+		w := flag.CommandLine.Output()
+		fmt.Fprintf(w, "Usage of %s:\n", USAGE_STRING)
+		fmt.Fprintf(w, "  -%s: %v\n", DEBUG_NAME, DEBUG_USAGE)
+		fmt.Fprintf(w, "  -%s: %v\n", CONFIG_NAME, CONFIG_USAGE)
+		fmt.Fprintf(w, "  %s: %v\n", ADD_NAME, ADD_USAGE)
+		fmt.Fprintf(w, "  %s: %v\n", LEARNER_NAME, LEARNER_USAGE)
+		fmt.Fprintf(w, "  %s: %v\n", PROMOTE_NAME, PROMOTE_USAGE)
+		fmt.Fprintf(w, "  %s: %v\n", REMOVE_NAME, REMOVE_USAGE)
+		fmt.Fprintf(w, "  %s: %v\n", TRANSFER_NAME, TRANSFER_USAGE)
+	}
+
+	// Parse command-line arguments and flags
+	debug := flag.Bool("d", false, DEBUG_USAGE)
+	configFile := flag.String("f", "", "(required) Config file")
+	flag.Parse()
+
+	// Use tail arguments to hold non-flag arguments
+	args := flag.Args()
+
+	if len(args) < 2 {
+		flag.Usage()
+		os.Exit(EX_USAGE)
+	}
+	serverId, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		flag.Usage()
+		os.Exit(EX_USAGE)
+	}
+
+	var call func(ctx context.Context, client syncinator.RaftSyncinatorClient) error
+	switch {
+	case args[0] == "add" && len(args) == 3:
+		member := &syncinator.RaftMember{Id: serverId, Addr: args[2]}
+		call = func(ctx context.Context, client syncinator.RaftSyncinatorClient) error {
+			_, err := client.AddServer(ctx, member)
+			return err
+		}
+	case args[0] == "learner" && len(args) == 3:
+		member := &syncinator.RaftMember{Id: serverId, Addr: args[2], IsLearner: true}
+		call = func(ctx context.Context, client syncinator.RaftSyncinatorClient) error {
+			_, err := client.AddServer(ctx, member)
+			return err
+		}
+	case args[0] == "promote" && len(args) == 2:
+		member := &syncinator.RaftMember{Id: serverId}
+		call = func(ctx context.Context, client syncinator.RaftSyncinatorClient) error {
+			_, err := client.PromoteLearner(ctx, member)
+			return err
+		}
+	case args[0] == "remove" && len(args) == 2:
+		member := &syncinator.RaftMember{Id: serverId}
+		call = func(ctx context.Context, client syncinator.RaftSyncinatorClient) error {
+			_, err := client.RemoveServer(ctx, member)
+			return err
+		}
+	case args[0] == "transfer" && len(args) == 2:
+		input := &syncinator.TransferLeadershipInput{TargetId: serverId}
+		call = func(ctx context.Context, client syncinator.RaftSyncinatorClient) error {
+			_, err := client.TransferLeadership(ctx, input)
+			return err
+		}
+	default:
+		flag.Usage()
+		os.Exit(EX_USAGE)
+	}
+
+	// Disable log outputs if debug flag is missing
+	if !(*debug) {
+		log.SetFlags(0)
+		log.SetOutput(io.Discard)
+	}
+
+	config := syncinator.LoadRaftConfigFile(*configFile)
+	if err := CallLeader(config.RaftAddrs, call); err != nil {
+		fmt.Println("Command failed:", err)
+		os.Exit(1)
+	}
+	fmt.Println("Command succeeded")
+}
+
+// Tries every server until the leader accepts the command
+func CallLeader(raftAddrs []string, call func(ctx context.Context, client syncinator.RaftSyncinatorClient) error) error {
+	var lastErr error
+	for _, addr := range raftAddrs {
+		conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		client := syncinator.NewRaftSyncinatorClient(conn)
+
+		ctx, cancel := context.WithTimeout(context.Background(), ADMIN_TIMEOUT)
+		err = call(ctx, client)
+		cancel()
+		conn.Close()
+
+		if err == nil {
+			return nil
+		}
+		log.Printf("Server at %s rejected the command: %v", addr, err)
+		lastErr = err
+	}
+	return lastErr
+}
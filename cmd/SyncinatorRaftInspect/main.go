@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"cse224/proj5/pkg/syncinator"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Usage strings
+const USAGE_STRING = "./SyncinatorRaftInspect -d -f config_file.txt (status | dump serverId [start [end]] | diff serverId serverId [start [end]])"
+
+const DEBUG_NAME = "d"
+const DEBUG_USAGE = "Output log statements"
+
+const CONFIG_NAME = "f config_file.txt"
+const CONFIG_USAGE = "Path to config file that specifies addresses for all Raft nodes"
+
+const STATUS_NAME = "status"
+const STATUS_USAGE = "Print role, term, indexes and the leader's matchIndex of every server"
+
+const DUMP_NAME = "dump serverId [start [end]]"
+const DUMP_USAGE = "Print the server's log entries in [start, end)"
+
+const DIFF_NAME = "diff serverId serverId [start [end]]"
+const DIFF_USAGE = "Print the log entries in [start, end) that differ between the servers"
+
+// Exit codes
+const EX_USAGE int = 64
+
+const INSPECT_TIMEOUT = 5 * time.Second
+
+func main() {
+	// Custom flag Usage message
+	flag.Usage = func() {
+		w := flag.CommandLine.Output()
+		fmt.Fprintf(w, "Usage of %s:\n", USAGE_STRING)
+		fmt.Fprintf(w, "  -%s: %v\n", DEBUG_NAME, DEBUG_USAGE)
+		fmt.Fprintf(w, "  -%s: %v\n", CONFIG_NAME, CONFIG_USAGE)
+		fmt.Fprintf(w, "  %s: %v\n", STATUS_NAME, STATUS_USAGE)
+		fmt.Fprintf(w, "  %s: %v\n", DUMP_NAME, DUMP_USAGE)
+		fmt.Fprintf(w, "  %s: %v\n", DIFF_NAME, DIFF_USAGE)
+	}
+
+	// Parse command-line arguments and flags
+	debug := flag.Bool("d", false, DEBUG_USAGE)
+	configFile := flag.String("f", "", "(required) Config file")
+	flag.Parse()
+
+	// Use tail arguments to hold non-flag arguments
+	args := flag.Args()
+	if len(args) < 1 {
+		flag.Usage()
+		os.Exit(EX_USAGE)
+	}
+
+	// Disable log outputs if debug flag is missing
+	if !(*debug) {
+		log.SetFlags(0)
+		log.SetOutput(io.Discard)
+	}
+
+	config := syncinator.LoadRaftConfigFile(*configFile)
+	var err error
+	switch {
+	case args[0] == "status" && len(args) == 1:
+		err = PrintStatus(config.RaftAddrs)
+	case args[0] == "dump" && len(args) >= 2 && len(args) <= 4:
+		ids, start, end := parseArgs(args[1:], 1, len(config.RaftAddrs))
+		err = PrintLog(config.RaftAddrs[ids[0]], start, end)
+	case args[0] == "diff" && len(args) >= 3 && len(args) <= 5:
+		ids, start, end := parseArgs(args[1:], 2, len(config.RaftAddrs))
+		err = PrintLogDiff(config.RaftAddrs[ids[0]], config.RaftAddrs[ids[1]], start, end)
+	default:
+		flag.Usage()
+		os.Exit(EX_USAGE)
+	}
+	if err != nil {
+		fmt.Println("Command failed:", err)
+		os.Exit(1)
+	}
+}
+
+// Parses numIds server ids followed by an optional log range
+func parseArgs(args []string, numIds int, numServers int) ([]int64, int64, int64) {
+	values := make([]int64, 0, len(args))
+	for _, arg := range args {
+		value, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || value < 0 {
+			flag.Usage()
+			os.Exit(EX_USAGE)
+		}
+		values = append(values, value)
+	}
+	ids := values[:numIds]
+	for _, id := range ids {
+		if id >= int64(numServers) {
+			flag.Usage()
+			os.Exit(EX_USAGE)
+		}
+	}
+
+	start, end := int64(0), int64(math.MaxInt64)
+	if len(values) > numIds {
+		start = values[numIds]
+	}
+	if len(values) > numIds+1 {
+		end = values[numIds+1]
+	}
+	return ids, start, end
+}
+
+func connect(addr string) (syncinator.RaftSyncinatorClient, *grpc.ClientConn, error) {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return syncinator.NewRaftSyncinatorClient(conn), conn, nil
+}
+
+func getStatus(addr string) (*syncinator.RaftStatus, error) {
+	client, conn, err := connect(addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), INSPECT_TIMEOUT)
+	defer cancel()
+	return client.GetRaftStatus(ctx, &emptypb.Empty{})
+}
+
+// Prints one row per server. The match column shows what the leader of the
+// highest term knows about each follower.
+func PrintStatus(raftAddrs []string) error {
+	statuses := make([]*syncinator.RaftStatus, len(raftAddrs))
+	errs := make([]error, len(raftAddrs))
+	var leader *syncinator.RaftStatus
+	for id, addr := range raftAddrs {
+		statuses[id], errs[id] = getStatus(addr)
+		if status := statuses[id]; status != nil && status.Status == syncinator.ServerStatus_LEADER {
+			if leader == nil || status.Term > leader.Term {
+				leader = status
+			}
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tADDR\tROLE\tTERM\tLEADER\tCOMMIT\tAPPLIED\tLOG\tSNAPSHOT\tMATCH")
+	for id, addr := range raftAddrs {
+		status := statuses[id]
+		if status == nil {
+			fmt.Fprintf(w, "%d\t%s\tunreachable: %v\n", id, addr, errs[id])
+			continue
+		}
+		role := status.Status.String()
+		if status.IsPaused {
+			role += " (paused)"
+		}
+		match := "-"
+		if leader != nil {
+			if matchIndex, ok := leader.MatchIndex[int64(id)]; ok {
+				match = strconv.FormatInt(matchIndex, 10)
+			}
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%s\n", id, addr, role, status.Term, status.LeaderId,
+			status.CommitIndex, status.LastApplied, status.LastLogIndex+1, status.SnapshotIndex, match)
+	}
+	return w.Flush()
+}
+
+// Fetches the entries in [start, end) page by page. Returns the index of the
+// first entry, which is past start if the rest is compacted.
+func getLog(addr string, start int64, end int64) (int64, []*syncinator.UpdateOperation, error) {
+	client, conn, err := connect(addr)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer conn.Close()
+
+	firstIndex := int64(-1)
+	entries := make([]*syncinator.UpdateOperation, 0)
+	for start < end {
+		ctx, cancel := context.WithTimeout(context.Background(), INSPECT_TIMEOUT)
+		logEntries, err := client.GetLogEntries(ctx, &syncinator.LogRange{StartIndex: start, EndIndex: end})
+		cancel()
+		if err != nil {
+			return 0, nil, err
+		}
+		if firstIndex == -1 {
+			firstIndex = logEntries.StartIndex
+		}
+		if len(logEntries.Entries) == 0 {
+			break
+		}
+		entries = append(entries, logEntries.Entries...)
+		start = logEntries.StartIndex + int64(len(logEntries.Entries))
+	}
+	return max(firstIndex, 0), entries, nil
+}
+
+func formatEntry(index int64, entry *syncinator.UpdateOperation) string {
+	if entry.Configuration != nil {
+		members := make([]string, 0, len(entry.Configuration.Members))
+		for _, member := range entry.Configuration.Members {
+			role := "voter"
+			if member.IsLearner {
+				role = "learner"
+			}
+			members = append(members, fmt.Sprintf("%d@%s(%s)", member.Id, member.Addr, role))
+		}
+		return fmt.Sprintf("%d\tterm %d\tconfig %s", index, entry.Term, strings.Join(members, " "))
+	}
+	fileMetaData := entry.FileMetaData
+	if fileMetaData == nil {
+		// Appended by a new leader to commit entries of earlier terms
+		return fmt.Sprintf("%d\tterm %d\tno-op", index, entry.Term)
+	}
+	return fmt.Sprintf("%d\tterm %d\tupdate %s v%d %v client %d seq %d", index, entry.Term, fileMetaData.GetFilename(),
+		fileMetaData.GetVersion(), fileMetaData.GetBlockHashList(), fileMetaData.GetClientId(), fileMetaData.GetSequence())
+}
+
+func PrintLog(addr string, start int64, end int64) error {
+	firstIndex, entries, err := getLog(addr, start, end)
+	if err != nil {
+		return err
+	}
+	if firstIndex > start {
+		fmt.Printf("Entries before %d are compacted into a snapshot\n", firstIndex)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for i, entry := range entries {
+		fmt.Fprintln(w, formatEntry(firstIndex+int64(i), entry))
+	}
+	return w.Flush()
+}
+
+// Prints the entries of the first server prefixed by "<" and of the second
+// by ">" at every index where they differ or only one has an entry
+func PrintLogDiff(addr1 string, addr2 string, start int64, end int64) error {
+	firstIndex1, entries1, err := getLog(addr1, start, end)
+	if err != nil {
+		return err
+	}
+	firstIndex2, entries2, err := getLog(addr2, start, end)
+	if err != nil {
+		return err
+	}
+
+	// Only entries that neither server has compacted can be compared
+	firstIndex := max(firstIndex1, firstIndex2)
+	if firstIndex > start {
+		fmt.Printf("Entries before %d are compacted into a snapshot\n", firstIndex)
+	}
+	entries1 = entries1[min(firstIndex-firstIndex1, int64(len(entries1))):]
+	entries2 = entries2[min(firstIndex-firstIndex2, int64(len(entries2))):]
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	firstDivergence := int64(-1)
+	for i := 0; i < max(len(entries1), len(entries2)); i++ {
+		index := firstIndex + int64(i)
+		var entry1, entry2 *syncinator.UpdateOperation
+		if i < len(entries1) {
+			entry1 = entries1[i]
+		}
+		if i < len(entries2) {
+			entry2 = entries2[i]
+		}
+		if entry1 != nil && entry2 != nil && proto.Equal(entry1, entry2) {
+			continue
+		}
+		if firstDivergence == -1 {
+			firstDivergence = index
+		}
+		if entry1 != nil {
+			fmt.Fprintln(w, "<\t"+formatEntry(index, entry1))
+		}
+		if entry2 != nil {
+			fmt.Fprintln(w, ">\t"+formatEntry(index, entry2))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if firstDivergence == -1 {
+		fmt.Printf("Logs match on %d entries from %d\n", len(entries1), firstIndex)
+	} else {
+		fmt.Printf("Logs diverge from index %d\n", firstDivergence)
+	}
+	return nil
+}
@@ -11,9 +11,17 @@ func main() {
 	serverId := flag.Int64("i", -1, "(required) Server ID")
 	configFile := flag.String("f", "", "(required) Config file, absolute path")
 	debug := flag.Bool("d", false, "Output log statements")
+	dataDir := flag.String("dir", "", "Directory for durable Raft state, overrides DataDir in config file")
+	joinAddr := flag.String("join", "", "Address to listen on when joining a running cluster, the leader must then add this server")
 	flag.Parse()
 
 	config := syncinator.LoadRaftConfigFile(*configFile)
+	if *dataDir != "" {
+		config.DataDir = *dataDir
+	}
+	if *joinAddr != "" {
+		config.JoinAddr = *joinAddr
+	}
 
 	// Disable log outputs if debug flag is missing
 	if !(*debug) {
@@ -15,7 +15,7 @@ import (
 )
 
 // Usage String
-const USAGE_STRING = "./run-server.sh -s <service_type> -p <port> -l -d (blockStoreAddr*)"
+const USAGE_STRING = "./run-server.sh -s <service_type> -p <port> -l -d -metadb <path> -dir <path> -maxblock <bytes> (blockStoreAddr*)"
 
 // Set of valid services
 var SERVICE_TYPES = map[string]bool{"meta": true, "block": true, "both": true}
@@ -39,6 +39,9 @@ func main() {
 	port := flag.Int("p", 8080, "(default = 8080) Port to accept connections")
 	localOnly := flag.Bool("l", false, "Only listen on localhost")
 	debug := flag.Bool("d", false, "Output log statements")
+	metaDB := flag.String("metadb", "", "SQLite database for file metadata, kept in memory only if empty")
+	blockDir := flag.String("dir", "", "Directory for blocks, kept in memory only if empty")
+	maxBlockSize := flag.Int("maxblock", syncinator.DEFAULT_MAX_BLOCK_SIZE, "Largest block to accept, in bytes")
 	flag.Parse()
 
 	// Use tail arguments to hold BlockStore address
@@ -54,6 +57,13 @@ func main() {
 		os.Exit(EX_USAGE)
 	}
 
+	// Blocks of at least one byte must be accepted
+	if *maxBlockSize <= 0 {
+		fmt.Fprintf(flag.CommandLine.Output(), "-maxblock must be positive, got %d\n", *maxBlockSize)
+		flag.Usage()
+		os.Exit(EX_USAGE)
+	}
+
 	// Add localhost if necessary
 	addr := ""
 	if *localOnly {
@@ -67,21 +77,28 @@ func main() {
 		log.SetOutput(io.Discard)
 	}
 
-	log.Fatal(startServer(addr, strings.ToLower(*service), blockStoreAddrs))
+	log.Fatal(startServer(addr, strings.ToLower(*service), blockStoreAddrs, *metaDB, *blockDir, *maxBlockSize))
 }
 
-func startServer(hostAddr string, serviceType string, blockStoreAddrs []string) error {
+func startServer(hostAddr string, serviceType string, blockStoreAddrs []string, metaDB string, blockDir string, maxBlockSize int) error {
 	// Create a new Server
 	grpcServer := grpc.NewServer()
 
 	// Register rpc services
-	if serviceType == "meta" {
-		syncinator.RegisterMetaStoreServer(grpcServer, syncinator.NewMetaStore(blockStoreAddrs))
-	} else if serviceType == "block" {
-		syncinator.RegisterBlockStoreServer(grpcServer, syncinator.NewBlockStore())
-	} else {
-		syncinator.RegisterMetaStoreServer(grpcServer, syncinator.NewMetaStore(blockStoreAddrs))
-		syncinator.RegisterBlockStoreServer(grpcServer, syncinator.NewBlockStore())
+	if serviceType == "meta" || serviceType == "both" {
+		metaStore, err := newMetaStore(blockStoreAddrs, metaDB)
+		if err != nil {
+			return err
+		}
+		syncinator.RegisterMetaStoreServer(grpcServer, metaStore)
+	}
+	if serviceType == "block" || serviceType == "both" {
+		blockStore, err := newBlockStore(blockDir)
+		if err != nil {
+			return err
+		}
+		blockStore.MaxBlockSize = maxBlockSize
+		syncinator.RegisterBlockStoreServer(grpcServer, blockStore)
 	}
 	l, e := net.Listen("tcp", hostAddr)
 	if e != nil {
@@ -90,3 +107,25 @@ func startServer(hostAddr string, serviceType string, blockStoreAddrs []string)
 	fmt.Printf("Server started at %s\n", hostAddr)
 	return grpcServer.Serve(l)
 }
+
+func newMetaStore(blockStoreAddrs []string, metaDB string) (*syncinator.MetaStore, error) {
+	if metaDB == "" {
+		return syncinator.NewMetaStore(blockStoreAddrs), nil
+	}
+	storage, err := syncinator.NewSQLiteMetaStorage(metaDB)
+	if err != nil {
+		return nil, err
+	}
+	return syncinator.NewMetaStoreWithStorage(blockStoreAddrs, storage), nil
+}
+
+func newBlockStore(blockDir string) (*syncinator.BlockStore, error) {
+	if blockDir == "" {
+		return syncinator.NewBlockStore(), nil
+	}
+	storage, err := syncinator.NewDiskBlockStorage(blockDir)
+	if err != nil {
+		return nil, err
+	}
+	return syncinator.NewBlockStoreWithStorage(storage), nil
+}
@@ -0,0 +1,276 @@
+package syncinator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// BlockStorage holds the blocks of a BlockStore, keyed by the hash of their data.
+// Implementations must be safe for concurrent use.
+type BlockStorage interface {
+	// Returns the block with the given hash, or false if it is not stored
+	Get(hash string) (*Block, bool, error)
+
+	// Stores the block under hash, a block that is already stored is kept as is
+	Put(hash string, block *Block) error
+
+	Has(hash string) bool
+
+	// Returns the hashes of all stored blocks
+	Hashes() []string
+}
+
+/*
+	Sharded map
+
+	Blocks are spread over BLOCK_MAP_SHARDS maps by the hash of their key, each
+	with its own lock, so requests for different blocks rarely wait on each other
+*/
+
+type blockMapShard[V any] struct {
+	mutex sync.RWMutex
+	items map[string]V
+	// Items whose creation is under way outside of the lock, closed once it ends
+	pending map[string]chan struct{}
+}
+
+type shardedBlockMap[V any] struct {
+	shards [BLOCK_MAP_SHARDS]*blockMapShard[V]
+}
+
+func newShardedBlockMap[V any]() *shardedBlockMap[V] {
+	m := &shardedBlockMap[V]{}
+	for i := range m.shards {
+		m.shards[i] = &blockMapShard[V]{
+			items:   make(map[string]V),
+			pending: make(map[string]chan struct{}),
+		}
+	}
+	return m
+}
+
+func (m *shardedBlockMap[V]) shard(hash string) *blockMapShard[V] {
+	h := fnv.New32a()
+	h.Write([]byte(hash))
+	return m.shards[h.Sum32()%uint32(BLOCK_MAP_SHARDS)]
+}
+
+func (m *shardedBlockMap[V]) get(hash string) (V, bool) {
+	shard := m.shard(hash)
+	shard.mutex.RLock()
+	defer shard.mutex.RUnlock()
+	item, ok := shard.items[hash]
+	return item, ok
+}
+
+// Stores the item made by create unless hash is already present. create runs
+// without the lock of the shard, so slow creations such as disk writes do not
+// hold up other keys; concurrent calls for the same hash wait for the one
+// running, and only take over if it fails. The item becomes visible once created.
+func (m *shardedBlockMap[V]) putIfAbsent(hash string, create func() (V, error)) error {
+	shard := m.shard(hash)
+	for {
+		shard.mutex.Lock()
+		if _, ok := shard.items[hash]; ok {
+			shard.mutex.Unlock()
+			return nil
+		}
+		pending, ok := shard.pending[hash]
+		if !ok {
+			break
+		}
+		shard.mutex.Unlock()
+		<-pending
+	}
+	pending := make(chan struct{})
+	shard.pending[hash] = pending
+	shard.mutex.Unlock()
+
+	item, err := create()
+
+	shard.mutex.Lock()
+	delete(shard.pending, hash)
+	if err == nil {
+		shard.items[hash] = item
+	}
+	shard.mutex.Unlock()
+	close(pending)
+	return err
+}
+
+func (m *shardedBlockMap[V]) keys() []string {
+	keys := make([]string, 0)
+	for _, shard := range m.shards {
+		shard.mutex.RLock()
+		for key := range shard.items {
+			keys = append(keys, key)
+		}
+		shard.mutex.RUnlock()
+	}
+	return keys
+}
+
+/*
+	In-memory storage
+*/
+
+type memoryBlockStorage struct {
+	blockMap *shardedBlockMap[*Block]
+}
+
+func NewMemoryBlockStorage() BlockStorage {
+	return &memoryBlockStorage{blockMap: newShardedBlockMap[*Block]()}
+}
+
+func (m *memoryBlockStorage) Get(hash string) (*Block, bool, error) {
+	block, ok := m.blockMap.get(hash)
+	return block, ok, nil
+}
+
+func (m *memoryBlockStorage) Put(hash string, block *Block) error {
+	return m.blockMap.putIfAbsent(hash, func() (*Block, error) {
+		return block, nil
+	})
+}
+
+func (m *memoryBlockStorage) Has(hash string) bool {
+	_, ok := m.blockMap.get(hash)
+	return ok
+}
+
+func (m *memoryBlockStorage) Hashes() []string {
+	return m.blockMap.keys()
+}
+
+/*
+	Disk storage
+
+	Each block is a file named by its hash, in a directory named by the first
+	BLOCK_SHARD_PREFIX_LEN characters of the hash, so no directory grows too large:
+
+		dir/3f/3fa9...e1
+*/
+
+type diskBlockStorage struct {
+	dir string
+
+	// Hashes of the blocks on disk, rebuilt on startup
+	index *shardedBlockMap[bool]
+}
+
+// NewDiskBlockStorage opens, or creates, the block directory dir and indexes the blocks in it
+func NewDiskBlockStorage(dir string) (BlockStorage, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	storage := &diskBlockStorage{
+		dir:   dir,
+		index: newShardedBlockMap[bool](),
+	}
+	if err := storage.rebuildIndex(); err != nil {
+		return nil, err
+	}
+	return storage, nil
+}
+
+// Indexes every block file, and removes the temp files of writes cut short by a crash
+func (d *diskBlockStorage) rebuildIndex() error {
+	shards, err := os.ReadDir(d.dir)
+	if err != nil {
+		return err
+	}
+	for _, shard := range shards {
+		if !shard.IsDir() {
+			continue
+		}
+		shardDir := filepath.Join(d.dir, shard.Name())
+		entries, err := os.ReadDir(shardDir)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if strings.HasSuffix(name, TEMP_FILE_SUFFIX) {
+				if err := os.Remove(filepath.Join(shardDir, name)); err != nil {
+					return err
+				}
+				continue
+			}
+			if entry.IsDir() || !isValidBlockHash(name) || blockShard(name) != shard.Name() {
+				continue
+			}
+			d.index.putIfAbsent(name, func() (bool, error) {
+				return true, nil
+			})
+		}
+	}
+	return nil
+}
+
+func (d *diskBlockStorage) Get(hash string) (*Block, bool, error) {
+	if !d.Has(hash) {
+		return nil, false, nil
+	}
+	data, err := os.ReadFile(d.blockPath(hash))
+	if err != nil {
+		return nil, false, fmt.Errorf("could not read block %s: %w", hash, err)
+	}
+	return &Block{BlockData: data, BlockSize: int32(len(data))}, true, nil
+}
+
+func (d *diskBlockStorage) Put(hash string, block *Block) error {
+	if !isValidBlockHash(hash) {
+		return fmt.Errorf("invalid block hash %q", hash)
+	}
+
+	// Concurrent puts of the same block write it once, other blocks are written in
+	// parallel, and the block is indexed only once it is on disk
+	return d.index.putIfAbsent(hash, func() (bool, error) {
+		shardDir := filepath.Join(d.dir, blockShard(hash))
+		if _, err := os.Stat(shardDir); os.IsNotExist(err) {
+			// Blocks of another index shard may create the directory at the same time
+			if err := os.Mkdir(shardDir, 0755); err != nil && !os.IsExist(err) {
+				return false, err
+			}
+			if err := syncDir(d.dir); err != nil {
+				return false, err
+			}
+		}
+		// The block only appears under its hash once all of it is on disk
+		if err := writeFileAtomic(d.blockPath(hash), block.BlockData); err != nil {
+			return false, fmt.Errorf("could not write block %s: %w", hash, err)
+		}
+		return true, nil
+	})
+}
+
+func (d *diskBlockStorage) Has(hash string) bool {
+	_, ok := d.index.get(hash)
+	return ok
+}
+
+func (d *diskBlockStorage) Hashes() []string {
+	return d.index.keys()
+}
+
+func (d *diskBlockStorage) blockPath(hash string) string {
+	return filepath.Join(d.dir, blockShard(hash), hash)
+}
+
+func blockShard(hash string) string {
+	return hash[:BLOCK_SHARD_PREFIX_LEN]
+}
+
+// Only hex SHA-256 hashes name blocks, which also keeps requested hashes from escaping the directory
+func isValidBlockHash(hash string) bool {
+	if len(hash) != BLOCK_HASH_LEN || strings.ToLower(hash) != hash {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
@@ -2,27 +2,54 @@ package syncinator
 
 import (
 	context "context"
+	"io"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 type BlockStore struct {
-	BlockMap map[string]*Block
+	Storage BlockStorage
+	// Largest block PutBlock accepts, in bytes
+	MaxBlockSize int
 	UnimplementedBlockStoreServer
 }
 
+// Fails with NotFound if the block is not stored, and with DataLoss if its
+// data no longer hashes to its key
 func (bs *BlockStore) GetBlock(ctx context.Context, blockHash *BlockHash) (*Block, error) {
-	block, ok := bs.BlockMap[blockHash.Hash]
+	block, ok, err := bs.Storage.Get(blockHash.Hash)
+	if err != nil {
+		return nil, err
+	}
 	if !ok {
-		return &Block{}, nil
-	} else {
-		return block, nil
+		return nil, status.Errorf(codes.NotFound, "%v: %s", ErrBlockNotFound, blockHash.Hash)
+	}
+	if GetBlockHashString(block.BlockData) != blockHash.Hash {
+		return nil, status.Errorf(codes.DataLoss, "%v: %s", ErrBlockCorrupt, blockHash.Hash)
 	}
+	return block, nil
 }
 
+// Fails with InvalidArgument, storing nothing, if the block is too large, its
+// size is not that of its data, or its data does not hash to the expected hash
 func (bs *BlockStore) PutBlock(ctx context.Context, block *Block) (*Success, error) {
+	if len(block.BlockData) > bs.MaxBlockSize {
+		return nil, status.Errorf(codes.InvalidArgument, "%v: %d bytes, at most %d allowed", ErrBlockTooLarge, len(block.BlockData), bs.MaxBlockSize)
+	}
+	if int(block.BlockSize) != len(block.BlockData) {
+		return nil, status.Errorf(codes.InvalidArgument, "%v: size %d, %d bytes of data", ErrBlockSizeMismatch, block.BlockSize, len(block.BlockData))
+	}
 	blockHash := GetBlockHashString(block.BlockData)
-	bs.BlockMap[blockHash] = block
+	if block.ExpectedHash != "" && block.ExpectedHash != blockHash {
+		return nil, status.Errorf(codes.InvalidArgument, "%v: expected %s, got %s", ErrBlockHashMismatch, block.ExpectedHash, blockHash)
+	}
+	// The expected hash only checks the upload, readers get the data alone
+	stored := &Block{BlockData: block.BlockData, BlockSize: block.BlockSize}
+	if err := bs.Storage.Put(blockHash, stored); err != nil {
+		return nil, err
+	}
 	return &Success{Flag: true}, nil
 }
 
@@ -31,7 +58,7 @@ func (bs *BlockStore) PutBlock(ctx context.Context, block *Block) (*Success, err
 func (bs *BlockStore) MissingBlocks(ctx context.Context, blockHashesIn *BlockHashes) (*BlockHashes, error) {
 	missingHashes := []string{}
 	for _, hash := range blockHashesIn.Hashes {
-		if _, ok := bs.BlockMap[hash]; !ok {
+		if !bs.Storage.Has(hash) {
 			missingHashes = append(missingHashes, hash)
 		}
 	}
@@ -40,18 +67,56 @@ func (bs *BlockStore) MissingBlocks(ctx context.Context, blockHashesIn *BlockHas
 
 // Return a list containing all blockHashes on this block server
 func (bs *BlockStore) GetBlockHashes(ctx context.Context, _ *emptypb.Empty) (*BlockHashes, error) {
-	allHashes := []string{}
-	for hash := range bs.BlockMap {
-		allHashes = append(allHashes, hash)
+	return &BlockHashes{Hashes: bs.Storage.Hashes()}, nil
+}
+
+// Streams the blocks of the requested hashes as they arrive. gRPC flow control
+// holds back the client's hashes while it is not reading the blocks.
+func (bs *BlockStore) GetBlocks(stream BlockStore_GetBlocksServer) error {
+	for {
+		blockHash, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		block, err := bs.GetBlock(stream.Context(), blockHash)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(block); err != nil {
+			return err
+		}
+	}
+}
+
+// Stores the streamed blocks one at a time, with the checks of PutBlock
+func (bs *BlockStore) PutBlocks(stream BlockStore_PutBlocksServer) error {
+	for {
+		block, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&Success{Flag: true})
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := bs.PutBlock(stream.Context(), block); err != nil {
+			return err
+		}
 	}
-	return &BlockHashes{Hashes: allHashes}, nil
 }
 
 // This line guarantees all method for BlockStore are implemented
 var _ BlockStoreInterface = new(BlockStore)
 
 func NewBlockStore() *BlockStore {
+	return NewBlockStoreWithStorage(NewMemoryBlockStorage())
+}
+
+func NewBlockStoreWithStorage(storage BlockStorage) *BlockStore {
 	return &BlockStore{
-		BlockMap: map[string]*Block{},
+		Storage:      storage,
+		MaxBlockSize: DEFAULT_MAX_BLOCK_SIZE,
 	}
 }
@@ -0,0 +1,213 @@
+package syncinator
+
+import (
+	context "context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const META_STORAGE_MEMORY string = "memory"
+const META_STORAGE_SQLITE string = "sqlite"
+
+// MetaStorage holds the file metadata of a MetaStore. Implementations must be
+// safe for concurrent use, as gRPC runs every request on its own goroutine.
+type MetaStorage interface {
+	// Returns a copy of the metadata of every file
+	GetAll(ctx context.Context) (map[string]*FileMetaData, error)
+
+	// Stores fileMetaData only if its version is one above the stored version,
+	// zero for a new file, and returns it. Returns -1 and stores nothing otherwise.
+	CompareAndSet(ctx context.Context, fileMetaData *FileMetaData) (int32, error)
+
+	// Replaces all metadata with fileInfoMap
+	Replace(ctx context.Context, fileInfoMap map[string]*FileMetaData) error
+
+	Close() error
+}
+
+/*
+	In-memory storage
+*/
+
+type memoryMetaStorage struct {
+	mutex       sync.RWMutex
+	fileMetaMap map[string]*FileMetaData
+}
+
+func NewMemoryMetaStorage() MetaStorage {
+	return &memoryMetaStorage{fileMetaMap: make(map[string]*FileMetaData)}
+}
+
+func (m *memoryMetaStorage) GetAll(ctx context.Context) (map[string]*FileMetaData, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return copyFileInfoMap(m.fileMetaMap), nil
+}
+
+func (m *memoryMetaStorage) CompareAndSet(ctx context.Context, fileMetaData *FileMetaData) (int32, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	oldVersion := int32(NON_EXIST_FILE_VERSION)
+	if old, ok := m.fileMetaMap[fileMetaData.Filename]; ok {
+		oldVersion = old.Version
+	}
+	if fileMetaData.Version != oldVersion+1 {
+		return -1, nil
+	}
+	m.fileMetaMap[fileMetaData.Filename] = fileMetaData
+	return fileMetaData.Version, nil
+}
+
+func (m *memoryMetaStorage) Replace(ctx context.Context, fileInfoMap map[string]*FileMetaData) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.fileMetaMap = copyFileInfoMap(fileInfoMap)
+	return nil
+}
+
+// File metadata is never modified in place, so sharing the values is safe
+func copyFileInfoMap(fileInfoMap map[string]*FileMetaData) map[string]*FileMetaData {
+	copied := make(map[string]*FileMetaData, len(fileInfoMap))
+	for filename, fileMetaData := range fileInfoMap {
+		copied[filename] = fileMetaData
+	}
+	return copied
+}
+
+func (m *memoryMetaStorage) Close() error {
+	return nil
+}
+
+/*
+	SQLite storage
+*/
+
+const createFilesTable string = `create table if not exists files (
+		fileName TEXT PRIMARY KEY,
+		version INT NOT NULL,
+		hashList TEXT NOT NULL
+	);`
+
+const selectAllFiles string = `SELECT fileName, version, hashList FROM files;`
+
+const selectFileVersion string = `SELECT version FROM files WHERE fileName = ?;`
+
+const upsertFile string = `INSERT INTO files (fileName, version, hashList) VALUES (?, ?, ?)
+		ON CONFLICT(fileName) DO UPDATE SET version = excluded.version, hashList = excluded.hashList;`
+
+const deleteAllFiles string = `DELETE FROM files;`
+
+// Every write is synced to disk before it commits, so an acknowledged update survives a crash
+const sqliteMetaOptions string = "?_journal_mode=WAL&_synchronous=FULL&_busy_timeout=5000&_txlock=immediate"
+
+type sqliteMetaStorage struct {
+	db *sql.DB
+}
+
+// NewSQLiteMetaStorage opens, or creates, the metadata database at path
+func NewSQLiteMetaStorage(path string) (MetaStorage, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+sqliteMetaOptions)
+	if err != nil {
+		return nil, err
+	}
+	// Writers queue up on the single connection instead of failing on a locked database
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(createFilesTable); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("could not create files table: %w", err)
+	}
+	return &sqliteMetaStorage{db: db}, nil
+}
+
+func (m *sqliteMetaStorage) GetAll(ctx context.Context) (map[string]*FileMetaData, error) {
+	rows, err := m.db.QueryContext(ctx, selectAllFiles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fileInfoMap := make(map[string]*FileMetaData)
+	for rows.Next() {
+		var filename, hashList string
+		var version int32
+		if err := rows.Scan(&filename, &version, &hashList); err != nil {
+			return nil, err
+		}
+		fileInfoMap[filename] = &FileMetaData{
+			Filename:      filename,
+			Version:       version,
+			BlockHashList: splitHashList(hashList),
+		}
+	}
+	return fileInfoMap, rows.Err()
+}
+
+func (m *sqliteMetaStorage) CompareAndSet(ctx context.Context, fileMetaData *FileMetaData) (int32, error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return -1, err
+	}
+	defer tx.Rollback()
+
+	oldVersion := int32(NON_EXIST_FILE_VERSION)
+	err = tx.QueryRowContext(ctx, selectFileVersion, fileMetaData.Filename).Scan(&oldVersion)
+	if err != nil && err != sql.ErrNoRows {
+		return -1, err
+	}
+	if fileMetaData.Version != oldVersion+1 {
+		return -1, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, upsertFile, fileMetaData.Filename, fileMetaData.Version, joinHashList(fileMetaData.BlockHashList)); err != nil {
+		return -1, err
+	}
+	if err := tx.Commit(); err != nil {
+		return -1, err
+	}
+	return fileMetaData.Version, nil
+}
+
+func (m *sqliteMetaStorage) Replace(ctx context.Context, fileInfoMap map[string]*FileMetaData) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, deleteAllFiles); err != nil {
+		return err
+	}
+	statement, err := tx.PrepareContext(ctx, upsertFile)
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+	for filename, fileMetaData := range fileInfoMap {
+		if _, err := statement.ExecContext(ctx, filename, fileMetaData.Version, joinHashList(fileMetaData.BlockHashList)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (m *sqliteMetaStorage) Close() error {
+	return m.db.Close()
+}
+
+// Block hashes never contain the delimiter
+func joinHashList(hashList []string) string {
+	return strings.Join(hashList, HASH_DELIMITER)
+}
+
+func splitHashList(hashList string) []string {
+	if hashList == "" {
+		return []string{}
+	}
+	return strings.Split(hashList, HASH_DELIMITER)
+}
@@ -7,29 +7,27 @@ import (
 )
 
 type MetaStore struct {
-	FileMetaMap        map[string]*FileMetaData
+	Storage            MetaStorage
 	BlockStoreAddrs    []string
 	ConsistentHashRing *ConsistentHashRing
 	UnimplementedMetaStoreServer
 }
 
 func (m *MetaStore) GetFileInfoMap(ctx context.Context, _ *emptypb.Empty) (*FileInfoMap, error) {
-	return &FileInfoMap{FileInfoMap: m.FileMetaMap}, nil
+	fileInfoMap, err := m.Storage.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &FileInfoMap{FileInfoMap: fileInfoMap}, nil
 }
 
+// Stores the update only if it is the next version of the file, as one transaction
 func (m *MetaStore) UpdateFile(ctx context.Context, fileMetaData *FileMetaData) (*Version, error) {
-	filename := fileMetaData.Filename
-	newVersion := fileMetaData.Version
-	oldVersion := int32(0)
-	if _, ok := m.FileMetaMap[filename]; ok {
-		oldVersion = m.FileMetaMap[filename].Version
-	}
-	if newVersion == oldVersion+1 {
-		m.FileMetaMap[filename] = fileMetaData
-		return &Version{Version: newVersion}, nil
-	} else {
-		return &Version{Version: -1}, nil
+	version, err := m.Storage.CompareAndSet(ctx, fileMetaData)
+	if err != nil {
+		return nil, err
 	}
+	return &Version{Version: version}, nil
 }
 
 func (m *MetaStore) GetBlockStoreMap(ctx context.Context, blockHashesIn *BlockHashes) (*BlockStoreMap, error) {
@@ -53,8 +51,12 @@ func (m *MetaStore) GetBlockStoreAddrs(ctx context.Context, _ *emptypb.Empty) (*
 var _ MetaStoreInterface = new(MetaStore)
 
 func NewMetaStore(blockStoreAddrs []string) *MetaStore {
+	return NewMetaStoreWithStorage(blockStoreAddrs, NewMemoryMetaStorage())
+}
+
+func NewMetaStoreWithStorage(blockStoreAddrs []string, storage MetaStorage) *MetaStore {
 	return &MetaStore{
-		FileMetaMap:        map[string]*FileMetaData{},
+		Storage:            storage,
 		BlockStoreAddrs:    blockStoreAddrs,
 		ConsistentHashRing: NewConsistentHashRing(blockStoreAddrs),
 	}
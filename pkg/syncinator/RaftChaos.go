@@ -0,0 +1,208 @@
+package syncinator
+
+import (
+	context "context"
+	"path"
+	"time"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Testing and inspection RPCs keep working on a paused server and are never dropped
+var chaosExemptMethods = map[string]bool{
+	"GetRaftStatus":             true,
+	"GetLogEntries":             true,
+	"GetInternalState":          true,
+	"Restore":                   true,
+	"Crash":                     true,
+	"MakeServerUnreachableFrom": true,
+	"SetLinkFaults":             true,
+	"Pause":                     true,
+	"Resume":                    true,
+}
+
+// Replaces all link faults, links left out become healthy
+func (s *RaftSyncinator) SetLinkFaults(ctx context.Context, faults *LinkFaults) (*Success, error) {
+	s.raftStateMutex.Lock()
+	defer s.raftStateMutex.Unlock()
+
+	s.linkFaults = make(map[int64]*LinkFault)
+	for _, fault := range faults.Links {
+		s.linkFaults[fault.ServerId] = fault
+	}
+	s.clientFault = faults.Clients
+	return &Success{Flag: true}, nil
+}
+
+// Freezes the server without crashing it: its RPCs, outgoing and incoming,
+// block and its election timer stops until Resume. Time keeps going, so a
+// paused leader wakes up to a cluster that has moved on.
+func (s *RaftSyncinator) Pause(ctx context.Context, _ *emptypb.Empty) (*Success, error) {
+	s.raftStateMutex.Lock()
+	defer s.raftStateMutex.Unlock()
+
+	if s.pauseChannel == nil {
+		s.pauseChannel = make(chan struct{})
+	}
+	return &Success{Flag: true}, nil
+}
+
+func (s *RaftSyncinator) Resume(ctx context.Context, _ *emptypb.Empty) (*Success, error) {
+	s.raftStateMutex.Lock()
+	defer s.raftStateMutex.Unlock()
+
+	if s.pauseChannel != nil {
+		close(s.pauseChannel)
+		s.pauseChannel = nil
+	}
+	return &Success{Flag: true}, nil
+}
+
+// Drops all link faults and resumes the server
+func (s *RaftSyncinator) clearChaos() {
+	s.SetLinkFaults(context.Background(), &LinkFaults{})
+	s.Resume(context.Background(), &emptypb.Empty{})
+}
+
+// Locked
+func (s *RaftSyncinator) isPaused() bool {
+	return s.pauseChannel != nil
+}
+
+func (s *RaftSyncinator) waitUnpaused(ctx context.Context) error {
+	s.raftStateMutex.RLock()
+	pauseChannel := s.pauseChannel
+	s.raftStateMutex.RUnlock()
+	if pauseChannel == nil {
+		return nil
+	}
+	select {
+	case <-pauseChannel:
+		return nil
+	case <-ctx.Done():
+		return contextError(ctx)
+	}
+}
+
+// Waits out a pause, then drops or delays a message sent over the link to
+// peerId, or from clients if isClient
+func (s *RaftSyncinator) injectFaults(ctx context.Context, peerId int64, isClient bool) error {
+	if err := s.waitUnpaused(ctx); err != nil {
+		return err
+	}
+
+	s.raftStateMutex.Lock()
+	fault := s.linkFaults[peerId]
+	if isClient {
+		fault = s.clientFault
+	}
+	isDropped := fault != nil && (fault.IsPartitioned || s.rand.Float64() < fault.DropRate)
+	delay := time.Duration(fault.GetDelayMillis()) * time.Millisecond
+	s.raftStateMutex.Unlock()
+
+	if isDropped {
+		return status.Error(codes.Unavailable, ErrMessageDropped.Error())
+	}
+	if delay > 0 {
+		timer := s.clock.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			return contextError(ctx)
+		}
+	}
+	return nil
+}
+
+// Returns the server that sent a request, or false if it came from a client
+func senderOf(req interface{}) (int64, bool) {
+	switch input := req.(type) {
+	case *AppendEntryInput:
+		return input.LeaderId, true
+	case *RequestVoteInput:
+		return input.CandidateId, true
+	case *InstallSnapshotInput:
+		return input.LeaderId, true
+	case *TimeoutNowInput:
+		return input.LeaderId, true
+	}
+	return NO_LEADER, false
+}
+
+// Applies the client faults to client requests, and the faults of the link
+// back to the sender to replies to peers. Peers apply their own link faults
+// to the requests they send.
+func (s *RaftSyncinator) chaosInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	method := path.Base(info.FullMethod)
+	if method == "Restore" {
+		// A restored server starts over with healthy links. Restore itself
+		// does not clear them, callers that bypass gRPC do it themselves.
+		s.clearChaos()
+	}
+	if chaosExemptMethods[method] {
+		return handler(ctx, req)
+	}
+
+	senderId, isFromPeer := senderOf(req)
+	if !isFromPeer {
+		if err := s.injectFaults(ctx, NO_LEADER, true); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+
+	if err := s.waitUnpaused(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	if err := s.injectFaults(ctx, senderId, false); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Applies my link faults to the requests I send to a peer
+type chaosPeerClient struct {
+	server *RaftSyncinator
+	peerId int64
+	client RaftPeerClient
+}
+
+func (c *chaosPeerClient) AppendEntries(ctx context.Context, in *AppendEntryInput, opts ...grpc.CallOption) (*AppendEntryOutput, error) {
+	if err := c.server.injectFaults(ctx, c.peerId, false); err != nil {
+		return nil, err
+	}
+	return c.client.AppendEntries(ctx, in, opts...)
+}
+
+func (c *chaosPeerClient) RequestVote(ctx context.Context, in *RequestVoteInput, opts ...grpc.CallOption) (*RequestVoteOutput, error) {
+	if err := c.server.injectFaults(ctx, c.peerId, false); err != nil {
+		return nil, err
+	}
+	return c.client.RequestVote(ctx, in, opts...)
+}
+
+func (c *chaosPeerClient) InstallSnapshot(ctx context.Context, in *InstallSnapshotInput, opts ...grpc.CallOption) (*InstallSnapshotOutput, error) {
+	if err := c.server.injectFaults(ctx, c.peerId, false); err != nil {
+		return nil, err
+	}
+	return c.client.InstallSnapshot(ctx, in, opts...)
+}
+
+func (c *chaosPeerClient) TimeoutNow(ctx context.Context, in *TimeoutNowInput, opts ...grpc.CallOption) (*Success, error) {
+	if err := c.server.injectFaults(ctx, c.peerId, false); err != nil {
+		return nil, err
+	}
+	return c.client.TimeoutNow(ctx, in, opts...)
+}
+
+func (c *chaosPeerClient) Close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,307 @@
+package syncinator
+
+import (
+	context "context"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Source of time for election timeouts, heartbeats, leases and deadlines.
+// Servers use the real clock unless RaftConfig.Clock is set, e.g. to a
+// SimClock.
+type RaftClock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	NewTimer(d time.Duration) RaftTimer
+	NewTicker(d time.Duration) RaftTimer
+	WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc)
+}
+
+// A timer or ticker of a RaftClock
+type RaftTimer interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (realClock) NewTimer(d time.Duration) RaftTimer {
+	return realTimer{timer: time.NewTimer(d)}
+}
+
+func (realClock) NewTicker(d time.Duration) RaftTimer {
+	return realTicker{ticker: time.NewTicker(d)}
+}
+
+func (realClock) WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, d)
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t realTimer) Stop() {
+	t.timer.Stop()
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t realTicker) Stop() {
+	t.ticker.Stop()
+}
+
+// A clock that only moves when advanced. It fires one timer at a time, and
+// waits for the goroutines it woke to settle before firing the next, so a
+// simulation on one thread (GOMAXPROCS=1) runs the same way every time. Timers
+// due at the same instant fire in the order of their owner, then of their
+// creation by that owner.
+type SimClock struct {
+	mutex  sync.Mutex
+	now    time.Time
+	timers []*simTimer
+	// Timers created so far by each owner
+	numTimers map[int64]uint64
+}
+
+type simTimer struct {
+	clock  *SimClock
+	at     time.Time
+	order  simTimerOrder
+	period time.Duration
+	c      chan time.Time
+}
+
+// Orders timers due at the same instant. Servers own the timers they create,
+// messages in flight are ordered by their link and content.
+type simTimerOrder struct {
+	isMessage bool
+	owner     int64
+	peer      int64
+	seq       uint64
+}
+
+// The clock as seen by one owner of timers
+type simOwnerClock struct {
+	clock *SimClock
+	owner int64
+}
+
+// Every simulation starts at the same instant
+func NewSimClock() *SimClock {
+	return &SimClock{
+		now:       time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		numTimers: make(map[int64]uint64),
+	}
+}
+
+// Returns the clock a server, or any other owner of timers, runs on
+func (c *SimClock) For(owner int64) RaftClock {
+	return simOwnerClock{clock: c, owner: owner}
+}
+
+func (c *SimClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *SimClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+func (c simOwnerClock) Now() time.Time {
+	return c.clock.Now()
+}
+
+func (c simOwnerClock) Since(t time.Time) time.Duration {
+	return c.clock.Since(t)
+}
+
+func (c simOwnerClock) NewTimer(d time.Duration) RaftTimer {
+	return c.clock.addTimer(d, 0, c.nextOrder())
+}
+
+func (c simOwnerClock) NewTicker(d time.Duration) RaftTimer {
+	return c.clock.addTimer(d, d, c.nextOrder())
+}
+
+// Cancels the context once d of simulated time has passed
+func (c simOwnerClock) WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	timer := c.NewTimer(d)
+	go func() {
+		defer timer.Stop()
+		select {
+		case <-timer.C():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+func (c simOwnerClock) nextOrder() simTimerOrder {
+	c.clock.mutex.Lock()
+	defer c.clock.mutex.Unlock()
+	seq := c.clock.numTimers[c.owner]
+	c.clock.numTimers[c.owner]++
+	return simTimerOrder{owner: c.owner, seq: seq}
+}
+
+func (c *SimClock) addTimer(d time.Duration, period time.Duration, order simTimerOrder) *simTimer {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	// Buffered like the channels of time.Timer, late ticks are dropped
+	timer := &simTimer{clock: c, at: c.now.Add(d), order: order, period: period, c: make(chan time.Time, 1)}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+// Moves the clock forward by d, firing the timers that come due on the way
+func (c *SimClock) Advance(d time.Duration) {
+	waitForIdle()
+	end := c.Now().Add(d)
+	for c.step(end) {
+	}
+}
+
+// Fires the next timer due by end, and waits until the goroutines it woke
+// are blocked again. Returns false, with the clock moved to end, if no
+// timer is due by then.
+func (c *SimClock) step(end time.Time) bool {
+	c.mutex.Lock()
+	var next *simTimer
+	for _, timer := range c.timers {
+		if !timer.at.After(end) && (next == nil || timer.isBefore(next)) {
+			next = timer
+		}
+	}
+	if next == nil {
+		c.now = end
+		c.mutex.Unlock()
+		return false
+	}
+	c.now = next.at
+	select {
+	case next.c <- c.now:
+	default:
+	}
+	if next.period > 0 {
+		next.at = next.at.Add(next.period)
+	} else {
+		c.removeTimer(next)
+	}
+	c.mutex.Unlock()
+
+	waitForIdle()
+	return true
+}
+
+// Locked
+func (c *SimClock) removeTimer(timer *simTimer) {
+	for i, t := range c.timers {
+		if t == timer {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (t *simTimer) isBefore(other *simTimer) bool {
+	if !t.at.Equal(other.at) {
+		return t.at.Before(other.at)
+	}
+	a, b := t.order, other.order
+	switch {
+	case a.isMessage != b.isMessage:
+		return !a.isMessage
+	case a.owner != b.owner:
+		return a.owner < b.owner
+	case a.peer != b.peer:
+		return a.peer < b.peer
+	}
+	return a.seq < b.seq
+}
+
+func (t *simTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *simTimer) Stop() {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+	t.clock.removeTimer(t)
+}
+
+// Blocks until every other goroutine of the process is blocked, e.g. on a
+// timer, a channel or a lock, as found in a dump of their states. Gives up
+// after SIM_IDLE_TIMEOUT, in case a goroutine outside of the simulation
+// keeps running.
+func waitForIdle() {
+	deadline := time.Now().Add(SIM_IDLE_TIMEOUT)
+	dump := make([]byte, 1<<16)
+	for time.Now().Before(deadline) {
+		runtime.Gosched()
+		n := runtime.Stack(dump, true)
+		if n == len(dump) {
+			dump = make([]byte, 2*len(dump))
+			continue
+		}
+		if areOthersBlocked(dump[:n]) {
+			return
+		}
+	}
+}
+
+// The dump lists my own goroutine first, then the others with their
+// states, e.g. "goroutine 7 [chan receive, 2 minutes]:"
+func areOthersBlocked(dump []byte) bool {
+	isMine := true
+	for _, line := range strings.Split(string(dump), "\n") {
+		if !strings.HasPrefix(line, "goroutine ") {
+			continue
+		}
+		if isMine {
+			isMine = false
+			continue
+		}
+		start, end := strings.IndexByte(line, '['), strings.IndexAny(line, ",]")
+		if start == -1 || end < start {
+			return false
+		}
+		if simBusyStates[line[start+1:end]] {
+			return false
+		}
+	}
+	return true
+}
+
+var simBusyStates = map[string]bool{
+	"running":        true,
+	"runnable":       true,
+	"syscall":        true,
+	"preempted":      true,
+	"copystack":      true,
+	"GC assist wait": true,
+}
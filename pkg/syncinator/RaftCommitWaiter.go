@@ -0,0 +1,72 @@
+package syncinator
+
+import (
+	context "context"
+)
+
+// A request that appends an entry waits for its outcome in a registry keyed
+// by log index. Each waiter is resolved exactly once, with
+//   - the response, when its entry is applied
+//   - ErrNotCommitted, when another leader's entry is applied at its index,
+//     so its own entry can never commit
+//   - ErrCommitUnknown, once leadership is lost, since the entry may still be
+//     committed by the next leader
+
+type commitWaiter struct {
+	term     int64
+	done     chan struct{}
+	response *UpdateFileResponse
+	err      error
+}
+
+// Locked
+func (s *RaftSyncinator) registerCommitWaiter(logIndex int64, logTerm int64) *commitWaiter {
+	waiter := &commitWaiter{term: logTerm, done: make(chan struct{})}
+	s.commitWaiters[logIndex] = waiter
+	return waiter
+}
+
+// Locked
+// Called for every applied entry, response is nil unless it updated a file
+func (s *RaftSyncinator) resolveCommitWaiter(logIndex int64, logTerm int64, response *UpdateFileResponse) {
+	waiter, ok := s.commitWaiters[logIndex]
+	if !ok {
+		return
+	}
+	delete(s.commitWaiters, logIndex)
+	if waiter.term != logTerm {
+		// Another leader's entry took the index
+		waiter.err = ErrNotCommitted
+	} else {
+		waiter.response = response
+	}
+	close(waiter.done)
+}
+
+// Locked
+// Fails the waiters of entries appended up to leaderTerm, whose leadership is lost
+func (s *RaftSyncinator) failCommitWaiters(leaderTerm int64) {
+	for logIndex, waiter := range s.commitWaiters {
+		if waiter.term > leaderTerm {
+			continue
+		}
+		delete(s.commitWaiters, logIndex)
+		waiter.err = ErrCommitUnknown
+		close(waiter.done)
+	}
+}
+
+// Blocks until the waiter of logIndex is resolved, or the request is cancelled
+func (s *RaftSyncinator) waitForCommit(ctx context.Context, logIndex int64, waiter *commitWaiter) (*UpdateFileResponse, error) {
+	select {
+	case <-waiter.done:
+		return waiter.response, waiter.err
+	case <-ctx.Done():
+		s.raftStateMutex.Lock()
+		if s.commitWaiters[logIndex] == waiter {
+			delete(s.commitWaiters, logIndex)
+		}
+		s.raftStateMutex.Unlock()
+		return nil, contextError(ctx)
+	}
+}
@@ -2,11 +2,71 @@ package syncinator
 
 import (
 	"fmt"
+	"time"
 )
 
 var ErrServerCrashedUnreachable = fmt.Errorf("server is crashed or unreachable")
 var ErrServerCrashed = fmt.Errorf("server is crashed")
 var ErrNotLeader = fmt.Errorf("server is not the leader")
+var ErrCommitUnknown = fmt.Errorf("leadership was lost before the update committed, its outcome is unknown")
+var ErrNotCommitted = fmt.Errorf("another leader's entry took the place of the update, it was not committed")
+var ErrLeadershipUnconfirmed = fmt.Errorf("leadership could not be confirmed by a majority")
+var ErrReplicaBehind = fmt.Errorf("replica is too far behind the leader")
+var ErrStaleRequest = fmt.Errorf("request was superseded by a newer request of the same client")
+var ErrTransferInProgress = fmt.Errorf("leadership transfer is in progress")
+var ErrInvalidTransferTarget = fmt.Errorf("leadership transfer target is not a voter")
+var ErrConfigChangeInProgress = fmt.Errorf("another configuration change is in progress")
+var ErrNotLearner = fmt.Errorf("server is not a learner")
+var ErrLearnerBehind = fmt.Errorf("learner has not caught up with the leader")
+var ErrMessageDropped = fmt.Errorf("message was dropped by an injected fault")
+var ErrInvalidMetaStorage = fmt.Errorf("invalid meta storage")
+
+// Timing
+
+const ELECTION_TIMEOUT_MIN = 400 * time.Millisecond
+const ELECTION_TIMEOUT_MAX = 800 * time.Millisecond
+const ELECTION_TICK = 10 * time.Millisecond
+const HEARTBEAT_INTERVAL = 100 * time.Millisecond
+const RPC_TIMEOUT = 200 * time.Millisecond
+const READ_INDEX_TIMEOUT = ELECTION_TIMEOUT_MAX
+const TRANSFER_TIMEOUT = ELECTION_TIMEOUT_MAX
+const SET_LEADER_TIMEOUT = ELECTION_TIMEOUT_MAX
+const PROMOTE_TIMEOUT = 5 * time.Second
+const CHECK_QUORUM_TIMEOUT = ELECTION_TIMEOUT_MAX
+
+// How long a simulation waits in real time for its goroutines to block
+const SIM_IDLE_TIMEOUT = time.Second
+
+// Owns the timers of a simulated fault schedule
+const SIM_SCHEDULE_OWNER int64 = -1
+
+// Fates of a simulated message, as recorded in the trace
+const SIM_DELIVERED string = "delivered"
+const SIM_DROPPED string = "dropped"
+const SIM_CUT_OFF string = "cut off"
+
+// Leases are shorter than the minimum election timeout by the bound on clock drift
+const CLOCK_DRIFT_BOUND = 50 * time.Millisecond
+const LEASE_DURATION = ELECTION_TIMEOUT_MIN - CLOCK_DRIFT_BOUND
+
+// Followers stop serving replica reads once they lose contact with the leader for this long
+const MAX_REPLICA_STALENESS = ELECTION_TIMEOUT_MAX
+
+const NO_VOTE int64 = -1
+const NO_TERM int64 = -1
+const NO_LEADER int64 = -1
+
+const DEFAULT_SNAPSHOT_THRESHOLD int64 = 1000
+
+// Replication sends bounded batches, and pipelines them to up-to-date followers
+const MAX_APPEND_ENTRIES_BATCH int = 256
+const MAX_APPEND_ENTRIES_BYTES int = 1 << 20
+const MAX_INFLIGHT_APPEND_ENTRIES int = 4
+
+const MAX_CLIENT_SESSIONS int = 10000
+
+// Log entries returned by one GetLogEntries
+const MAX_INSPECT_ENTRIES int64 = 1000
 
 // Enums
 
@@ -0,0 +1,333 @@
+package syncinator
+
+import (
+	context "context"
+	"log"
+	"time"
+)
+
+// Locked
+func (s *RaftSyncinator) resetElectionTimer() {
+	s.lastContact = s.clock.Now()
+	s.electionTimeout = ELECTION_TIMEOUT_MIN + time.Duration(s.rand.Int63n(int64(ELECTION_TIMEOUT_MAX-ELECTION_TIMEOUT_MIN)))
+}
+
+// Locked
+func (s *RaftSyncinator) isLogUpToDate(lastLogIndex int64, lastLogTerm int64) bool {
+	myLastLogIndex := s.lastLogIndex()
+	myLastLogTerm := s.logTerm(myLastLogIndex)
+	if lastLogTerm != myLastLogTerm {
+		return lastLogTerm > myLastLogTerm
+	}
+	return lastLogIndex >= myLastLogIndex
+}
+
+func (s *RaftSyncinator) runElectionTimer() {
+	ticker := s.clock.NewTicker(ELECTION_TICK)
+	defer ticker.Stop()
+
+	for range ticker.C() {
+		s.serverStatusMutex.RLock()
+		myStatus := s.serverStatus
+		s.serverStatusMutex.RUnlock()
+
+		s.raftStateMutex.Lock()
+		if s.isPaused() {
+			// A paused server notices nothing, and may time out right after resuming
+			s.raftStateMutex.Unlock()
+			continue
+		}
+		if myStatus == ServerStatus_LEADER {
+			// Leaders never time out, but step down once cut off from a majority
+			s.resetElectionTimer()
+			hasQuorumContact := s.hasQuorumContact()
+			leaderTerm := s.term
+			s.raftStateMutex.Unlock()
+			if !hasQuorumContact {
+				s.stepDown(leaderTerm)
+			}
+			continue
+		}
+		if myStatus == ServerStatus_CRASHED || !s.isVoter(s.id) {
+			// Crashed servers, learners and non-members never time out
+			s.resetElectionTimer()
+			s.raftStateMutex.Unlock()
+			continue
+		}
+		timedOut := s.clock.Since(s.lastContact) >= s.electionTimeout
+		s.raftStateMutex.Unlock()
+
+		if timedOut {
+			s.startPreVote()
+		}
+	}
+}
+
+// Locked
+// Check-quorum (§6.2 of the Raft dissertation): a leader that has not heard
+// from a majority within CHECK_QUORUM_TIMEOUT may be partitioned away
+func (s *RaftSyncinator) hasQuorumContact() bool {
+	since := s.clock.Now().Add(-CHECK_QUORUM_TIMEOUT)
+	if s.leaderSince.After(since) {
+		// Give a new leader time to reach its followers
+		return true
+	}
+
+	numContacted := 0
+	for peerId := range s.peers {
+		if s.learners[peerId] {
+			continue
+		}
+		if peerId == s.id || s.ackedAt[peerId].After(since) {
+			numContacted++
+		}
+	}
+	return numContacted >= s.m
+}
+
+// Steps down to follower without a newer term, if still the leader of leaderTerm
+func (s *RaftSyncinator) stepDown(leaderTerm int64) {
+	s.serverStatusMutex.Lock()
+	s.raftStateMutex.Lock()
+	if s.serverStatus == ServerStatus_LEADER && s.term == leaderTerm {
+		s.serverStatus = s.followerStatus()
+		s.leaderId = NO_LEADER
+		s.resetElectionTimer()
+		// Wake up requests waiting on a lost leadership
+		s.failCommitWaiters(leaderTerm)
+		s.notifyCommit()
+		s.notifyAck()
+		log.Printf("Server %d steps down as leader of term %d", s.id, leaderTerm)
+	}
+	s.raftStateMutex.Unlock()
+	s.serverStatusMutex.Unlock()
+}
+
+// Pre-vote (§9.6 of the Raft dissertation): before bumping its term, a
+// candidate asks whether a majority would vote for it. A server returning
+// from a partition fails the pre-vote and cannot depose a healthy leader.
+// The returned channel reports whether I won the election, it is nil if I am
+// crashed or already the leader.
+func (s *RaftSyncinator) startPreVote() <-chan bool {
+	s.serverStatusMutex.RLock()
+	myStatus := s.serverStatus
+	s.serverStatusMutex.RUnlock()
+	if myStatus == ServerStatus_CRASHED || myStatus == ServerStatus_LEADER {
+		return nil
+	}
+
+	s.raftStateMutex.Lock()
+	// Retry after the next timeout if the pre-vote fails
+	s.resetElectionTimer()
+	preVoteTerm := s.term
+	preVoteInput := &RequestVoteInput{
+		Term:         s.term + 1,
+		CandidateId:  s.id,
+		LastLogIndex: s.lastLogIndex(),
+		LastLogTerm:  s.logTerm(s.lastLogIndex()),
+
+		PreVote: true,
+	}
+	quorum := s.m
+	clients := make([]RaftPeerClient, 0)
+	for _, peerId := range s.getVoterIds() {
+		clients = append(clients, s.getPeerClient(peerId))
+	}
+	s.raftStateMutex.Unlock()
+
+	log.Printf("Server %d starts pre-vote for term %d", s.id, preVoteInput.Term)
+
+	voteChannel := make(chan *RequestVoteOutput, len(clients))
+	for _, client := range clients {
+		go s.requestVoteFromPeer(client, preVoteInput, voteChannel)
+	}
+
+	isElected := make(chan bool, 1)
+	go func() {
+		if !s.countVotes(preVoteTerm, len(clients), quorum, voteChannel) {
+			isElected <- false
+			return
+		}
+		s.raftStateMutex.RLock()
+		// A leader may have shown up during the pre-vote
+		isStale := s.term != preVoteTerm || s.isLeaderAlive()
+		s.raftStateMutex.RUnlock()
+		if isStale {
+			isElected <- false
+			return
+		}
+		if electionResult := s.startElection(false); electionResult != nil {
+			isElected <- <-electionResult
+		} else {
+			isElected <- false
+		}
+	}()
+	return isElected
+}
+
+// Runs pre-votes every HEARTBEAT_INTERVAL until I win an election or
+// timeout has passed. Returns ErrNotLeader if I never win.
+func (s *RaftSyncinator) campaignUntil(ctx context.Context, timeout time.Duration) error {
+	deadline := s.clock.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		isElected := s.startPreVote()
+		if isElected == nil {
+			// Crashed or already the leader, as the heartbeats will tell
+			return nil
+		}
+		select {
+		case ok := <-isElected:
+			if ok {
+				return nil
+			}
+		case <-ctx.Done():
+			return contextError(ctx)
+		}
+
+		retry := s.clock.NewTimer(HEARTBEAT_INTERVAL)
+		select {
+		case <-retry.C():
+		case <-deadline.C():
+			retry.Stop()
+			return ErrNotLeader
+		case <-ctx.Done():
+			retry.Stop()
+			return contextError(ctx)
+		}
+	}
+}
+
+// isTransfer marks an election requested by the leader through TimeoutNow.
+// The returned channel reports whether I won, it is nil if I am crashed or
+// already the leader.
+func (s *RaftSyncinator) startElection(isTransfer bool) <-chan bool {
+	s.serverStatusMutex.Lock()
+	if s.serverStatus == ServerStatus_CRASHED || s.serverStatus == ServerStatus_LEADER {
+		s.serverStatusMutex.Unlock()
+		return nil
+	}
+	s.serverStatus = ServerStatus_CANDIDATE
+	s.serverStatusMutex.Unlock()
+
+	s.raftStateMutex.Lock()
+	s.term++
+	s.votedFor = s.id
+	s.leaderId = NO_LEADER
+	s.persistState()
+	s.resetElectionTimer()
+	requestVoteInput := &RequestVoteInput{
+		Term:         s.term,
+		CandidateId:  s.id,
+		LastLogIndex: s.lastLogIndex(),
+		LastLogTerm:  s.logTerm(s.lastLogIndex()),
+
+		LeadershipTransfer: isTransfer,
+	}
+	// Votes are counted against the configuration the election started with
+	quorum := s.m
+	clients := make([]RaftPeerClient, 0)
+	for _, peerId := range s.getVoterIds() {
+		clients = append(clients, s.getPeerClient(peerId))
+	}
+	s.raftStateMutex.Unlock()
+
+	log.Printf("Server %d starts election for term %d", s.id, requestVoteInput.Term)
+
+	// Each peer reports exactly once, nil if unreachable
+	voteChannel := make(chan *RequestVoteOutput, len(clients))
+	for _, client := range clients {
+		go s.requestVoteFromPeer(client, requestVoteInput, voteChannel)
+	}
+
+	isElected := make(chan bool, 1)
+	go s.collectVotes(requestVoteInput.Term, len(clients), quorum, voteChannel, isElected)
+	return isElected
+}
+
+func (s *RaftSyncinator) requestVoteFromPeer(client RaftPeerClient, input *RequestVoteInput, voteChannel chan<- *RequestVoteOutput) {
+	ctx, cancel := s.clock.WithTimeout(s.getNewContext(), RPC_TIMEOUT)
+	defer cancel()
+	output, err := client.RequestVote(ctx, input)
+	if err != nil {
+		voteChannel <- nil
+		return
+	}
+	voteChannel <- output
+}
+
+// Returns whether a quorum granted the votes, counting my own. Voters reply
+// with their own term, which is only newer than myTerm if they rejected me.
+func (s *RaftSyncinator) countVotes(myTerm int64, numPeers int, quorum int, voteChannel <-chan *RequestVoteOutput) bool {
+	numVotes := 1
+	for i := 0; i < numPeers && numVotes < quorum; i++ {
+		output := <-voteChannel
+		if output == nil {
+			continue
+		}
+		if output.VoteGranted {
+			numVotes++
+		} else if output.Term > myTerm {
+			// A newer term exists, give up the election
+			s.becomeFollower(output.Term)
+			return false
+		}
+	}
+	return numVotes >= quorum
+}
+
+func (s *RaftSyncinator) collectVotes(electionTerm int64, numPeers int, quorum int, voteChannel <-chan *RequestVoteOutput, isElected chan<- bool) {
+	if !s.countVotes(electionTerm, numPeers, quorum, voteChannel) {
+		// Split vote or unreachable majority, wait for the next timeout
+		isElected <- false
+		return
+	}
+
+	s.serverStatusMutex.Lock()
+	s.raftStateMutex.Lock()
+	if s.serverStatus != ServerStatus_CANDIDATE || s.term != electionTerm {
+		// Stale election result
+		s.raftStateMutex.Unlock()
+		s.serverStatusMutex.Unlock()
+		isElected <- false
+		return
+	}
+	s.promoteToLeader()
+	s.raftStateMutex.Unlock()
+	s.serverStatusMutex.Unlock()
+	isElected <- true
+
+	log.Printf("Server %d is elected leader for term %d", s.id, electionTerm)
+}
+
+// Both status and state locked
+func (s *RaftSyncinator) promoteToLeader() {
+	s.serverStatus = ServerStatus_LEADER
+	s.leaderId = s.id
+	s.initLeaderStates()
+	// Append no-op entry
+	s.log = append(s.log, &UpdateOperation{Term: s.term, FileMetaData: nil})
+	s.persistLog(s.lastLogIndex())
+	s.startReplication()
+}
+
+// Steps down to follower if term is newer than the current term
+func (s *RaftSyncinator) becomeFollower(term int64) {
+	s.serverStatusMutex.Lock()
+	s.raftStateMutex.Lock()
+	if term > s.term {
+		if s.serverStatus != ServerStatus_CRASHED {
+			s.serverStatus = s.followerStatus()
+		}
+		s.term = term
+		s.votedFor = NO_VOTE
+		s.leaderId = NO_LEADER
+		s.persistState()
+		// Wake up requests waiting on a lost leadership
+		s.failCommitWaiters(term)
+		s.notifyCommit()
+	}
+	s.raftStateMutex.Unlock()
+	s.serverStatusMutex.Unlock()
+}
@@ -0,0 +1,54 @@
+package syncinator
+
+import (
+	context "context"
+
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Reports where the server is in the log, even if crashed or paused
+func (s *RaftSyncinator) GetRaftStatus(ctx context.Context, _ *emptypb.Empty) (*RaftStatus, error) {
+	s.serverStatusMutex.RLock()
+	s.raftStateMutex.RLock()
+	defer s.raftStateMutex.RUnlock()
+	defer s.serverStatusMutex.RUnlock()
+
+	raftStatus := &RaftStatus{
+		ServerId:      s.id,
+		Status:        s.serverStatus,
+		Term:          s.term,
+		LeaderId:      s.leaderId,
+		CommitIndex:   s.commitIndex,
+		LastApplied:   s.lastApplied,
+		LastLogIndex:  s.lastLogIndex(),
+		SnapshotIndex: s.snapshotIndex,
+		IsPaused:      s.isPaused(),
+	}
+	if s.serverStatus == ServerStatus_LEADER {
+		raftStatus.MatchIndex = make(map[int64]int64)
+		for _, peerId := range s.getPeerIds() {
+			raftStatus.MatchIndex[peerId] = s.matchIndex[peerId]
+		}
+	}
+	return raftStatus, nil
+}
+
+// Returns up to MAX_INSPECT_ENTRIES entries of the range that are still in
+// the log, entries up to snapshotIndex are compacted
+func (s *RaftSyncinator) GetLogEntries(ctx context.Context, logRange *LogRange) (*LogEntries, error) {
+	s.raftStateMutex.RLock()
+	defer s.raftStateMutex.RUnlock()
+
+	startIndex := max(logRange.StartIndex, s.snapshotIndex+1)
+	endIndex := min(logRange.EndIndex, s.lastLogIndex()+1, startIndex+MAX_INSPECT_ENTRIES)
+	entries := make([]*UpdateOperation, 0)
+	for i := startIndex; i < endIndex; i++ {
+		entries = append(entries, s.logEntry(i))
+	}
+	return &LogEntries{
+		StartIndex:    startIndex,
+		Entries:       entries,
+		SnapshotIndex: s.snapshotIndex,
+		LastLogIndex:  s.lastLogIndex(),
+	}, nil
+}
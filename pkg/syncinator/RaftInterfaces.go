@@ -8,8 +8,18 @@ import (
 
 type RaftInterface interface {
 	AppendEntries(ctx context.Context, input *AppendEntryInput) (*AppendEntryOutput, error)
+	RequestVote(ctx context.Context, input *RequestVoteInput) (*RequestVoteOutput, error)
+	InstallSnapshot(ctx context.Context, input *InstallSnapshotInput) (*InstallSnapshotOutput, error)
 	SetLeader(ctx context.Context, _ *emptypb.Empty) (*Success, error)
 	SendHeartbeat(ctx context.Context, _ *emptypb.Empty) (*Success, error)
+	TransferLeadership(ctx context.Context, input *TransferLeadershipInput) (*Success, error)
+	TimeoutNow(ctx context.Context, input *TimeoutNowInput) (*Success, error)
+	AddServer(ctx context.Context, member *RaftMember) (*Success, error)
+	RemoveServer(ctx context.Context, member *RaftMember) (*Success, error)
+	PromoteLearner(ctx context.Context, member *RaftMember) (*Success, error)
+	GetReplicaFileInfoMap(ctx context.Context, input *ReplicaReadInput) (*ReplicaFileInfoMap, error)
+	GetRaftStatus(ctx context.Context, _ *emptypb.Empty) (*RaftStatus, error)
+	GetLogEntries(ctx context.Context, logRange *LogRange) (*LogEntries, error)
 }
 
 type RaftTestingInterface interface {
@@ -17,6 +27,9 @@ type RaftTestingInterface interface {
 	Crash(ctx context.Context, _ *emptypb.Empty) (*Success, error)
 	MakeServerUnreachableFrom(ctx context.Context, servers *UnreachableFromServers) (*Success, error)
 	Restore(ctx context.Context, _ *emptypb.Empty) (*Success, error)
+	SetLinkFaults(ctx context.Context, faults *LinkFaults) (*Success, error)
+	Pause(ctx context.Context, _ *emptypb.Empty) (*Success, error)
+	Resume(ctx context.Context, _ *emptypb.Empty) (*Success, error)
 }
 
 type RaftSyncinatorInterface interface {
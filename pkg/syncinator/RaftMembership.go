@@ -0,0 +1,298 @@
+package syncinator
+
+import (
+	context "context"
+	"log"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Servers listed in RaftAddrs form the initial configuration, a joining
+// server starts with an empty one and waits to be added by the leader
+func makeInitialConfiguration(config RaftConfig) *RaftConfiguration {
+	configuration := &RaftConfiguration{Members: make([]*RaftMember, 0)}
+	if config.JoinAddr != "" {
+		return configuration
+	}
+	isLearner := make(map[int64]bool)
+	for _, id := range config.Learners {
+		isLearner[id] = true
+	}
+	for id, addr := range config.RaftAddrs {
+		configuration.Members = append(configuration.Members, &RaftMember{Id: int64(id), Addr: addr, IsLearner: isLearner[int64(id)]})
+	}
+	return configuration
+}
+
+// Locked
+// Returns the latest configuration in the log up to index
+func (s *RaftSyncinator) configurationAt(index int64) (*RaftConfiguration, int64) {
+	for i := min(index, s.lastLogIndex()); i > s.snapshotIndex; i-- {
+		if configuration := s.logEntry(i).Configuration; configuration != nil {
+			return configuration, i
+		}
+	}
+	if s.snapshot != nil && s.snapshot.Configuration != nil {
+		return s.snapshot.Configuration, s.snapshotIndex
+	}
+	return s.initialConfiguration, -1
+}
+
+// Locked
+// A configuration takes effect as soon as it is in the log, so it must be
+// recomputed whenever the log is truncated or replaced
+func (s *RaftSyncinator) refreshConfiguration() {
+	configuration, index := s.configurationAt(s.lastLogIndex())
+	if index != s.configurationIndex || configuration != s.configuration {
+		s.applyConfiguration(configuration, index)
+	}
+}
+
+// Locked
+func (s *RaftSyncinator) applyConfiguration(configuration *RaftConfiguration, index int64) {
+	s.configuration = configuration
+	s.configurationIndex = index
+
+	s.peers = make(map[int64]string)
+	s.learners = make(map[int64]bool)
+	for _, member := range configuration.Members {
+		s.peers[member.Id] = member.Addr
+		if member.IsLearner {
+			s.learners[member.Id] = true
+		}
+		if _, ok := s.peerClients[member.Id]; !ok && member.Id != s.id {
+			if err := s.connectPeer(member.Id, member.Addr); err != nil {
+				log.Printf("Server %d could not connect to server %d at %s: %v", s.id, member.Id, member.Addr, err)
+			}
+		}
+	}
+	// Connections to removed members are closed, a later re-add dials afresh
+	for peerId, client := range s.peerClients {
+		if !s.isMember(peerId) {
+			if err := client.Close(); err != nil {
+				log.Printf("Server %d could not close its connection to server %d: %v", s.id, peerId, err)
+			}
+			delete(s.peerClients, peerId)
+		}
+	}
+	// Learners do not count toward the majority
+	s.n = len(s.peers) - len(s.learners)
+	s.m = s.n/2 + 1
+
+	log.Printf("Server %d uses configuration %v with learners %v at index %d", s.id, s.peers, s.learners, index)
+}
+
+// Locked
+func (s *RaftSyncinator) connectPeer(peerId int64, addr string) error {
+	client, err := s.transport.Connect(peerId, addr)
+	if err != nil {
+		return err
+	}
+	s.peerClients[peerId] = &chaosPeerClient{server: s, peerId: peerId, client: client}
+	return nil
+}
+
+// Locked
+func (s *RaftSyncinator) getPeerClient(peerId int64) RaftPeerClient {
+	return s.peerClients[peerId]
+}
+
+// Locked
+// Returns the ids of the other members in the current configuration
+func (s *RaftSyncinator) getPeerIds() []int64 {
+	peerIds := make([]int64, 0, len(s.peers))
+	for peerId := range s.peers {
+		if peerId != s.id {
+			peerIds = append(peerIds, peerId)
+		}
+	}
+	sort.Slice(peerIds, func(i, j int) bool { return peerIds[i] < peerIds[j] })
+	return peerIds
+}
+
+// Locked
+// Returns the ids of the other voters in the current configuration
+func (s *RaftSyncinator) getVoterIds() []int64 {
+	voterIds := make([]int64, 0, len(s.peers))
+	for _, peerId := range s.getPeerIds() {
+		if !s.learners[peerId] {
+			voterIds = append(voterIds, peerId)
+		}
+	}
+	return voterIds
+}
+
+// Locked
+func (s *RaftSyncinator) isMember(id int64) bool {
+	_, ok := s.peers[id]
+	return ok
+}
+
+// Locked
+func (s *RaftSyncinator) isVoter(id int64) bool {
+	return s.isMember(id) && !s.learners[id]
+}
+
+// Locked
+// Learners report their role in place of the follower status
+func (s *RaftSyncinator) followerStatus() ServerStatus {
+	if s.learners[s.id] {
+		return ServerStatus_LEARNER
+	}
+	return ServerStatus_FOLLOWER
+}
+
+// Switches between the follower and learner status after a configuration change
+func (s *RaftSyncinator) updateLearnerStatus() {
+	s.serverStatusMutex.Lock()
+	s.raftStateMutex.RLock()
+	if s.serverStatus == ServerStatus_FOLLOWER || s.serverStatus == ServerStatus_LEARNER {
+		s.serverStatus = s.followerStatus()
+	}
+	s.raftStateMutex.RUnlock()
+	s.serverStatusMutex.Unlock()
+}
+
+// Appends a configuration with one server added, removed or changing its
+// role, and waits for it to commit
+func (s *RaftSyncinator) changeConfiguration(ctx context.Context, member *RaftMember, isAdd bool) error {
+	// Check status
+	if _, err := s.checkStatus(false, -1); err != nil {
+		return err
+	}
+
+	// Only a leader may append a configuration and replicate it
+	if err := s.lockAsLeader(); err != nil {
+		return err
+	}
+	if s.transferTarget != NO_LEADER {
+		s.raftStateMutex.Unlock()
+		return ErrTransferInProgress
+	}
+	// Only one change at a time, and only once an entry of this term is committed
+	if s.configurationIndex > s.commitIndex || s.logTerm(s.commitIndex) != s.term {
+		s.raftStateMutex.Unlock()
+		return ErrConfigChangeInProgress
+	}
+	if s.isMember(member.Id) == isAdd && (!isAdd || s.learners[member.Id] == member.IsLearner) {
+		// Already applied
+		s.raftStateMutex.Unlock()
+		return nil
+	}
+
+	newConfiguration := &RaftConfiguration{Members: make([]*RaftMember, 0)}
+	for _, existing := range s.configuration.Members {
+		if existing.Id != member.Id {
+			newConfiguration.Members = append(newConfiguration.Members, existing)
+		}
+	}
+	if isAdd {
+		newConfiguration.Members = append(newConfiguration.Members, proto.Clone(member).(*RaftMember))
+	}
+
+	entry := &UpdateOperation{
+		Term:          s.term,
+		Configuration: newConfiguration,
+	}
+	s.log = append(s.log, entry)
+	requestLogIndex := s.lastLogIndex()
+	s.persistLog(requestLogIndex)
+	waiter := s.registerCommitWaiter(requestLogIndex, entry.Term)
+	s.applyConfiguration(newConfiguration, requestLogIndex)
+	s.syncReplicators()
+	// Replicate in the background
+	s.triggerReplication()
+	s.advanceCommitIndex()
+	s.raftStateMutex.Unlock()
+
+	// Wait until committed
+	if _, err := s.waitForCommit(ctx, requestLogIndex, waiter); err != nil {
+		return err
+	}
+
+	if member.Id == s.id && (!isAdd || member.IsLearner) {
+		// A removed or demoted leader steps down once the new configuration is committed
+		s.stepDown(entry.Term)
+		log.Printf("Server %d stepped down after leaving the voters", s.id)
+	}
+	return nil
+}
+
+// Waits until the learner has caught up with the commit index, then makes it a voter
+func (s *RaftSyncinator) promoteLearner(ctx context.Context, learnerId int64) error {
+	// Check status
+	if _, err := s.checkStatus(false, -1); err != nil {
+		return err
+	}
+
+	deadline := s.clock.NewTimer(PROMOTE_TIMEOUT)
+	defer deadline.Stop()
+
+	s.raftStateMutex.Lock()
+	if !s.isMember(learnerId) || !s.learners[learnerId] {
+		s.raftStateMutex.Unlock()
+		return ErrNotLearner
+	}
+	leaderTerm := s.term
+	s.triggerReplication()
+	for s.matchIndex[learnerId] < s.commitIndex {
+		if err := s.waitLocked(ctx, s.ackChannel, deadline.C(), leaderTerm); err == ErrLeadershipUnconfirmed {
+			s.raftStateMutex.Unlock()
+			return ErrLearnerBehind
+		} else if err != nil {
+			s.raftStateMutex.Unlock()
+			return err
+		}
+	}
+	member := &RaftMember{Id: learnerId, Addr: s.peers[learnerId], IsLearner: false}
+	s.raftStateMutex.Unlock()
+
+	return s.changeConfiguration(ctx, member, true)
+}
+
+// A new voter joins as a learner first, and only votes once it has caught up,
+// so that a server with an empty log never weakens the majority. If it does not
+// catch up in time it stays a learner, and can be promoted later.
+func (s *RaftSyncinator) addServer(ctx context.Context, member *RaftMember) error {
+	if member.IsLearner {
+		return s.changeConfiguration(ctx, member, true)
+	}
+
+	s.raftStateMutex.RLock()
+	isVoter := s.isVoter(member.Id)
+	isLearner := s.isMember(member.Id) && s.learners[member.Id]
+	s.raftStateMutex.RUnlock()
+	if isVoter {
+		// Already applied
+		return nil
+	}
+	if !isLearner {
+		learner := &RaftMember{Id: member.Id, Addr: member.Addr, IsLearner: true}
+		if err := s.changeConfiguration(ctx, learner, true); err != nil {
+			return err
+		}
+	}
+	return s.promoteLearner(ctx, member.Id)
+}
+
+func (s *RaftSyncinator) AddServer(ctx context.Context, member *RaftMember) (*Success, error) {
+	if err := s.addServer(ctx, member); err != nil {
+		return &Success{Flag: false}, err
+	}
+	return &Success{Flag: true}, nil
+}
+
+func (s *RaftSyncinator) PromoteLearner(ctx context.Context, member *RaftMember) (*Success, error) {
+	if err := s.promoteLearner(ctx, member.Id); err != nil {
+		return &Success{Flag: false}, err
+	}
+	return &Success{Flag: true}, nil
+}
+
+func (s *RaftSyncinator) RemoveServer(ctx context.Context, member *RaftMember) (*Success, error) {
+	if err := s.changeConfiguration(ctx, member, false); err != nil {
+		return &Success{Flag: false}, err
+	}
+	return &Success{Flag: true}, nil
+}
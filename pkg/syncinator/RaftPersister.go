@@ -0,0 +1,270 @@
+package syncinator
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const RAFT_STATE_FILENAME string = "state.pb"
+const RAFT_WAL_FILENAME string = "wal.log"
+const RAFT_SNAPSHOT_FILENAME string = "snapshot.pb"
+const RAFT_META_DB_FILENAME string = "meta.db"
+const TEMP_FILE_SUFFIX string = ".tmp"
+
+// Each WAL record is framed as | length uint32 | crc32 uint32 | RaftLogRecord |
+const WAL_HEADER_SIZE int = 8
+const WAL_MAX_RECORD_SIZE uint32 = 64 << 20
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// RaftPersister keeps the term, vote, snapshot and log of a Raft server on disk.
+// Every write is fsynced before it returns.
+type RaftPersister struct {
+	dir     string
+	walFile *os.File
+}
+
+func NewRaftPersister(dir string) (*RaftPersister, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	walFile, err := os.OpenFile(filepath.Join(dir, RAFT_WAL_FILENAME), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &RaftPersister{
+		dir:     dir,
+		walFile: walFile,
+	}, nil
+}
+
+// Atomically replaces the stable state file
+func (p *RaftPersister) SaveState(term int64, votedFor int64) error {
+	data, err := proto.Marshal(&RaftStableState{Term: term, VotedFor: votedFor})
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(p.dir, RAFT_STATE_FILENAME), data)
+}
+
+// Atomically replaces the snapshot, then rewrites the WAL to hold only the
+// entries that follow it. Stale WAL records left by a crash in between are
+// skipped on replay since they precede the snapshot.
+func (p *RaftPersister) SaveSnapshot(snapshot *RaftSnapshot, entries []*UpdateOperation) error {
+	data, err := proto.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(p.dir, RAFT_SNAPSHOT_FILENAME), data); err != nil {
+		return err
+	}
+
+	payload, err := proto.Marshal(&RaftLogRecord{StartIndex: snapshot.LastIncludedIndex + 1, Entries: entries})
+	if err != nil {
+		return err
+	}
+	walPath := filepath.Join(p.dir, RAFT_WAL_FILENAME)
+	if err := writeFileAtomic(walPath, frameWalRecord(payload)); err != nil {
+		return err
+	}
+	walFile, err := os.OpenFile(walPath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	p.walFile.Close()
+	p.walFile = walFile
+	return nil
+}
+
+// Records that the log is truncated to startIndex and followed by entries
+func (p *RaftPersister) AppendLog(startIndex int64, entries []*UpdateOperation) error {
+	payload, err := proto.Marshal(&RaftLogRecord{StartIndex: startIndex, Entries: entries})
+	if err != nil {
+		return err
+	}
+	record := frameWalRecord(payload)
+
+	if _, err := p.walFile.Seek(0, io.SeekEnd); err != nil {
+		return err
+	}
+	if _, err := p.walFile.Write(record); err != nil {
+		return err
+	}
+	return p.walFile.Sync()
+}
+
+// Replays the stable state, the snapshot and the WAL. Returned entries
+// follow the snapshot, or start at index 0 if there is none. A torn record
+// at the tail of the WAL, left by a crash in the middle of a write, is discarded.
+func (p *RaftPersister) Load() (term int64, votedFor int64, snapshot *RaftSnapshot, entries []*UpdateOperation, err error) {
+	term, votedFor = 0, NO_VOTE
+	state := &RaftStableState{}
+	if found, err := readProtoFile(filepath.Join(p.dir, RAFT_STATE_FILENAME), state); err != nil {
+		return 0, NO_VOTE, nil, nil, err
+	} else if found {
+		term, votedFor = state.Term, state.VotedFor
+	}
+
+	baseIndex := int64(0)
+	snapshot = &RaftSnapshot{}
+	if found, err := readProtoFile(filepath.Join(p.dir, RAFT_SNAPSHOT_FILENAME), snapshot); err != nil {
+		return 0, NO_VOTE, nil, nil, err
+	} else if found {
+		baseIndex = snapshot.LastIncludedIndex + 1
+	} else {
+		snapshot = nil
+	}
+
+	if _, err := p.walFile.Seek(0, io.SeekStart); err != nil {
+		return 0, NO_VOTE, nil, nil, err
+	}
+	entries = make([]*UpdateOperation, 0)
+	reader := bufio.NewReader(p.walFile)
+	validSize := int64(0)
+	for {
+		record, size, ok := readWalRecord(reader)
+		if !ok {
+			break
+		}
+		validSize += size
+
+		// Skip entries already covered by the snapshot
+		offset := record.StartIndex - baseIndex
+		recordEntries := record.Entries
+		if offset < 0 {
+			recordEntries = recordEntries[min(-offset, int64(len(recordEntries))):]
+			offset = 0
+		}
+		if offset > int64(len(entries)) {
+			return 0, NO_VOTE, nil, nil, fmt.Errorf("raft wal has a gap at index %d", baseIndex+int64(len(entries)))
+		}
+		entries = append(entries[:offset], recordEntries...)
+	}
+
+	// Drop the torn tail so new records are appended after valid ones
+	if err := p.walFile.Truncate(validSize); err != nil {
+		return 0, NO_VOTE, nil, nil, err
+	}
+	return term, votedFor, snapshot, entries, nil
+}
+
+func (p *RaftPersister) Close() error {
+	return p.walFile.Close()
+}
+
+func readProtoFile(path string, message proto.Message) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if err := proto.Unmarshal(data, message); err != nil {
+		return false, fmt.Errorf("corrupted raft file %s: %w", path, err)
+	}
+	return true, nil
+}
+
+func frameWalRecord(payload []byte) []byte {
+	record := make([]byte, WAL_HEADER_SIZE+len(payload))
+	binary.LittleEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(record[4:8], crc32.Checksum(payload, crcTable))
+	copy(record[WAL_HEADER_SIZE:], payload)
+	return record
+}
+
+func readWalRecord(reader *bufio.Reader) (*RaftLogRecord, int64, bool) {
+	header := make([]byte, WAL_HEADER_SIZE)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, 0, false
+	}
+	length := binary.LittleEndian.Uint32(header[0:4])
+	checksum := binary.LittleEndian.Uint32(header[4:8])
+	if length > WAL_MAX_RECORD_SIZE {
+		return nil, 0, false
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return nil, 0, false
+	}
+	if crc32.Checksum(payload, crcTable) != checksum {
+		return nil, 0, false
+	}
+	record := &RaftLogRecord{}
+	if err := proto.Unmarshal(payload, record); err != nil {
+		return nil, 0, false
+	}
+	return record, int64(WAL_HEADER_SIZE) + int64(length), true
+}
+
+// Locked
+func (s *RaftSyncinator) persistState() {
+	if s.persister == nil {
+		return
+	}
+	if err := s.persister.SaveState(s.term, s.votedFor); err != nil {
+		log.Fatal("Error During Raft State Persist ", err)
+	}
+}
+
+// Locked
+func (s *RaftSyncinator) persistLog(startIndex int64) {
+	if s.persister == nil {
+		return
+	}
+	if err := s.persister.AppendLog(startIndex, s.logFrom(startIndex)); err != nil {
+		log.Fatal("Error During Raft Log Persist ", err)
+	}
+}
+
+// Locked
+func (s *RaftSyncinator) persistSnapshot() {
+	if s.persister == nil {
+		return
+	}
+	if err := s.persister.SaveSnapshot(s.snapshot, s.log); err != nil {
+		log.Fatal("Error During Raft Snapshot Persist ", err)
+	}
+}
+
+// Writes to a temp file, fsyncs it and renames it over the target
+func writeFileAtomic(path string, data []byte) error {
+	tmpPath := path + TEMP_FILE_SUFFIX
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,180 @@
+package syncinator
+
+import (
+	context "context"
+	"sort"
+	"time"
+)
+
+// Reads follow the ReadIndex protocol (§6.4 of the Raft dissertation):
+// 1. Wait until an entry of the current term is committed
+// 2. Record the commit index as the read index
+// 3. Confirm leadership with a round of heartbeats acked by a majority,
+// unless the leader lease has not expired yet
+// 4. Wait until the state machine has applied the read index
+
+// Blocks until a read from the state machine is linearizable
+func (s *RaftSyncinator) waitForReadIndex(ctx context.Context) error {
+	// Under a valid lease, a read whose index is already applied only needs
+	// the read lock, so concurrent reads do not wait on each other
+	s.raftStateMutex.RLock()
+	isReadable := s.leaderId == s.id && s.logTerm(s.commitIndex) == s.term && s.isLeaseValid() && s.lastApplied >= s.commitIndex
+	s.raftStateMutex.RUnlock()
+	if isReadable {
+		return nil
+	}
+
+	deadline := s.clock.NewTimer(READ_INDEX_TIMEOUT)
+	defer deadline.Stop()
+
+	s.raftStateMutex.Lock()
+	defer s.raftStateMutex.Unlock()
+	leaderTerm := s.term
+
+	// Wait for the no-op entry of my term, so the commit index is up-to-date
+	for s.logTerm(s.commitIndex) != leaderTerm {
+		if err := s.waitLocked(ctx, s.commitChannel, deadline.C(), leaderTerm); err != nil {
+			return err
+		}
+	}
+	readIndex := s.commitIndex
+
+	if !s.isLeaseValid() {
+		// Concurrent reads are confirmed by the same heartbeats
+		s.readRound++
+		readRound := s.readRound
+		s.triggerReplication()
+		for !s.isReadRoundConfirmed(readRound) {
+			if err := s.waitLocked(ctx, s.ackChannel, deadline.C(), leaderTerm); err != nil {
+				return err
+			}
+		}
+	}
+
+	for s.lastApplied < readIndex {
+		if err := s.waitLocked(ctx, s.commitChannel, deadline.C(), leaderTerm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Locked
+// Releases the lock until channel is closed, and fails if the deadline
+// passes, the request is cancelled or leadership of leaderTerm is lost meanwhile
+func (s *RaftSyncinator) waitLocked(ctx context.Context, channel chan struct{}, deadline <-chan time.Time, leaderTerm int64) error {
+	s.raftStateMutex.Unlock()
+	var err error
+	select {
+	case <-channel:
+	case <-deadline:
+		err = ErrLeadershipUnconfirmed
+	case <-ctx.Done():
+		s.raftStateMutex.Lock()
+		return contextError(ctx)
+	}
+	s.raftStateMutex.Lock()
+
+	if s.term != leaderTerm || s.leaderId != s.id {
+		return ErrNotLeader
+	}
+	return err
+}
+
+// Locked
+// Records that peerId accepted my leadership in a request of readRound sent at sentAt
+func (s *RaftSyncinator) recordAck(peerId int64, readRound int64, sentAt time.Time) {
+	s.ackedRound[peerId] = max(s.ackedRound[peerId], readRound)
+	if sentAt.After(s.ackedAt[peerId]) {
+		s.ackedAt[peerId] = sentAt
+	}
+	s.notifyAck()
+}
+
+// Locked
+func (s *RaftSyncinator) notifyAck() {
+	close(s.ackChannel)
+	s.ackChannel = make(chan struct{})
+}
+
+// Locked
+func (s *RaftSyncinator) isReadRoundConfirmed(readRound int64) bool {
+	numAcked := 0
+	for peerId := range s.peers {
+		if s.learners[peerId] {
+			continue
+		}
+		if peerId == s.id {
+			numAcked++
+		} else if ackedRound, ok := s.ackedRound[peerId]; ok && ackedRound >= readRound {
+			numAcked++
+		}
+	}
+	return numAcked >= s.m
+}
+
+// Locked
+// A majority acked heartbeats sent less than LEASE_DURATION ago, and will
+// not vote for another candidate until ELECTION_TIMEOUT_MIN after receiving them
+func (s *RaftSyncinator) isLeaseValid() bool {
+	if !s.leaseReads || s.transferTarget != NO_LEADER {
+		// A transfer target may win an election while my lease lasts
+		return false
+	}
+
+	ackTimes := make([]time.Time, 0)
+	for peerId := range s.peers {
+		if s.learners[peerId] {
+			continue
+		}
+		if peerId == s.id {
+			ackTimes = append(ackTimes, s.clock.Now())
+		} else if ackedAt, ok := s.ackedAt[peerId]; ok {
+			ackTimes = append(ackTimes, ackedAt)
+		}
+	}
+	if len(ackTimes) < s.m {
+		return false
+	}
+
+	// The lease starts at the oldest ack among the latest majority
+	sort.Slice(ackTimes, func(i, j int) bool { return ackTimes[i].After(ackTimes[j]) })
+	return s.clock.Since(ackTimes[s.m-1]) < LEASE_DURATION
+}
+
+// Locked
+// Followers ignore candidates while the leader is alive, which keeps leases
+// valid and stops servers returning from a partition from deposing the leader
+func (s *RaftSyncinator) isLeaderAlive() bool {
+	return s.clock.Since(s.leaderContact) < ELECTION_TIMEOUT_MIN
+}
+
+// Blocks until minIndex is applied. Followers only serve while they hear
+// from a leader, so their data is at most MAX_REPLICA_STALENESS behind.
+func (s *RaftSyncinator) waitForReplicaIndex(ctx context.Context, myStatus ServerStatus, minIndex int64) error {
+	deadline := s.clock.NewTimer(READ_INDEX_TIMEOUT)
+	defer deadline.Stop()
+
+	s.raftStateMutex.Lock()
+	defer s.raftStateMutex.Unlock()
+
+	if myStatus != ServerStatus_LEADER && s.clock.Since(s.leaderContact) > MAX_REPLICA_STALENESS {
+		return ErrReplicaBehind
+	}
+
+	for s.lastApplied < minIndex {
+		commitChannel := s.commitChannel
+		s.raftStateMutex.Unlock()
+		select {
+		case <-commitChannel:
+			s.raftStateMutex.Lock()
+		case <-deadline.C():
+			s.raftStateMutex.Lock()
+			return ErrReplicaBehind
+		case <-ctx.Done():
+			s.raftStateMutex.Lock()
+			return contextError(ctx)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package syncinator
+
+import (
+	context "context"
+	"time"
+)
+
+// Both status and state locked
+func (s *RaftSyncinator) startReplication() {
+	// One replicator per follower for the current term
+	s.replicationTriggers = make(map[int64]chan struct{})
+	s.syncReplicators()
+}
+
+// Locked
+// Starts replicators for new members and stops those of removed members
+func (s *RaftSyncinator) syncReplicators() {
+	for peerId := range s.replicationTriggers {
+		if !s.isMember(peerId) {
+			delete(s.replicationTriggers, peerId)
+			// A removed server that is added again starts over, as a new one would
+			delete(s.nextIndex, peerId)
+			delete(s.matchIndex, peerId)
+			delete(s.ackedRound, peerId)
+			delete(s.ackedAt, peerId)
+		}
+	}
+	for _, peerId := range s.getPeerIds() {
+		if _, ok := s.replicationTriggers[peerId]; ok {
+			continue
+		}
+		if _, ok := s.nextIndex[peerId]; !ok {
+			s.nextIndex[peerId] = s.lastLogIndex() + 1
+			s.matchIndex[peerId] = -1
+		}
+		trigger := make(chan struct{}, 1)
+		s.replicationTriggers[peerId] = trigger
+		go s.replicateToFollower(peerId, s.term, s.getPeerClient(peerId), trigger)
+	}
+}
+
+// Locked
+func (s *RaftSyncinator) triggerReplication() {
+	for _, trigger := range s.replicationTriggers {
+		if trigger == nil {
+			continue
+		}
+		select {
+		case trigger <- struct{}{}:
+		default:
+			// A replication round is already pending
+		}
+	}
+}
+
+func (s *RaftSyncinator) isLeaderOf(leaderTerm int64) bool {
+	s.serverStatusMutex.RLock()
+	myStatus := s.serverStatus
+	s.serverStatusMutex.RUnlock()
+
+	s.raftStateMutex.RLock()
+	myTerm := s.term
+	s.raftStateMutex.RUnlock()
+
+	return myStatus == ServerStatus_LEADER && myTerm == leaderTerm
+}
+
+// Locked
+func (s *RaftSyncinator) isReplicatingTo(peerId int64, trigger chan struct{}) bool {
+	return s.replicationTriggers[peerId] == trigger
+}
+
+// Sends requests to the follower until leadership of leaderTerm is lost.
+// Once the follower's log matches mine, up to MAX_INFLIGHT_APPEND_ENTRIES
+// batches are in flight at a time, otherwise one probe at a time.
+func (s *RaftSyncinator) replicateToFollower(peerId int64, leaderTerm int64, client RaftPeerClient, trigger chan struct{}) {
+	ticker := s.clock.NewTicker(HEARTBEAT_INTERVAL)
+	defer ticker.Stop()
+
+	defer func() {
+		isLeader := s.isLeaderOf(leaderTerm)
+		// Wake up requests waiting on a lost leadership
+		s.raftStateMutex.Lock()
+		if !isLeader {
+			s.failCommitWaiters(leaderTerm)
+		}
+		s.notifyCommit()
+		s.raftStateMutex.Unlock()
+	}()
+
+	// Each in-flight request reports exactly once
+	outcomes := make(chan PeerInfo, MAX_INFLIGHT_APPEND_ENTRIES)
+	numInFlight := 0
+	isMatched := false
+
+	for s.isLeaderOf(leaderTerm) {
+		if !s.sendNextToFollower(peerId, leaderTerm, client, trigger, isMatched, outcomes) {
+			// Follower has been removed from the configuration
+			return
+		}
+		numInFlight++
+
+		shouldWait := !isMatched || !s.hasEntriesFor(peerId)
+		for numInFlight == MAX_INFLIGHT_APPEND_ENTRIES || (!isMatched && numInFlight > 0) || shouldWait {
+			select {
+			case outcome := <-outcomes:
+				numInFlight--
+				isMatched = outcome == PeerInfoSuccess
+				switch outcome {
+				case PeerInfoSuccess:
+					// Follower may still lag behind, keep sending
+					shouldWait = shouldWait && !s.hasEntriesFor(peerId)
+				case PeerInfoFail:
+					// Retry right away from the conflict
+					shouldWait = false
+				case PeerInfoUnreachable:
+					// Retry on the next heartbeat
+					shouldWait = true
+				}
+			case <-ticker.C():
+				shouldWait = false
+			case <-trigger:
+				shouldWait = false
+			}
+			if !s.isLeaderOf(leaderTerm) {
+				return
+			}
+		}
+	}
+}
+
+func (s *RaftSyncinator) hasEntriesFor(peerId int64) bool {
+	s.raftStateMutex.RLock()
+	defer s.raftStateMutex.RUnlock()
+	return s.nextIndex[peerId] <= s.lastLogIndex()
+}
+
+// Sends the next batch after nextIndex, or the snapshot if it has been
+// compacted, and reports the outcome in the background. If isPipelined,
+// nextIndex moves past the batch before the follower accepts it.
+// Returns false if the follower is no longer replicated to.
+func (s *RaftSyncinator) sendNextToFollower(peerId int64, leaderTerm int64, client RaftPeerClient, trigger chan struct{}, isPipelined bool, outcomes chan<- PeerInfo) bool {
+	sentAt := s.clock.Now()
+	s.raftStateMutex.Lock()
+	if !s.isReplicatingTo(peerId, trigger) {
+		s.raftStateMutex.Unlock()
+		return false
+	}
+	readRound := s.readRound
+
+	var send func(ctx context.Context) (*AppendEntryOutput, error)
+	if s.nextIndex[peerId] <= s.snapshotIndex {
+		installSnapshotInput := s.makeInstallSnapshotInput()
+		if isPipelined {
+			s.nextIndex[peerId] = s.snapshotIndex + 1
+		}
+		send = func(ctx context.Context) (*AppendEntryOutput, error) {
+			return s.installSnapshotOnFollower(ctx, client, installSnapshotInput)
+		}
+	} else {
+		appendEntryInput := s.makeAppendEntryInput(peerId)
+		if isPipelined {
+			s.nextIndex[peerId] = appendEntryInput.PrevLogIndex + int64(len(appendEntryInput.Entries)) + 1
+		}
+		send = func(ctx context.Context) (*AppendEntryOutput, error) {
+			return client.AppendEntries(ctx, appendEntryInput)
+		}
+	}
+	s.raftStateMutex.Unlock()
+
+	go func() {
+		ctx, cancel := s.clock.WithTimeout(s.getNewContext(), RPC_TIMEOUT)
+		output, err := send(ctx)
+		cancel()
+		if err != nil {
+			outcomes <- PeerInfoUnreachable
+			return
+		}
+		outcomes <- s.handleAppendEntryOutput(peerId, leaderTerm, output, readRound, sentAt)
+	}()
+	return true
+}
+
+// Sends the entries after nextIndex, or the snapshot if they have been compacted
+func (s *RaftSyncinator) sendLatestToFollower(ctx context.Context, client RaftPeerClient, peerId int64) (*AppendEntryOutput, error) {
+	s.raftStateMutex.RLock()
+	if s.nextIndex[peerId] <= s.snapshotIndex {
+		installSnapshotInput := s.makeInstallSnapshotInput()
+		s.raftStateMutex.RUnlock()
+		return s.installSnapshotOnFollower(ctx, client, installSnapshotInput)
+	}
+	appendEntryInput := s.makeAppendEntryInput(peerId)
+	s.raftStateMutex.RUnlock()
+
+	return client.AppendEntries(ctx, appendEntryInput)
+}
+
+// Reports the outcome of InstallSnapshot like that of AppendEntries
+func (s *RaftSyncinator) installSnapshotOnFollower(ctx context.Context, client RaftPeerClient, input *InstallSnapshotInput) (*AppendEntryOutput, error) {
+	output, err := client.InstallSnapshot(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	isCurrent := output.Term <= input.Term
+	return s.makeAppendEntryOutput(output.Term, output.ServerId, isCurrent, input.Snapshot.LastIncludedIndex), nil
+}
+
+func (s *RaftSyncinator) handleAppendEntryOutput(peerId int64, leaderTerm int64, output *AppendEntryOutput, readRound int64, sentAt time.Time) PeerInfo {
+	if output.Term > leaderTerm {
+		// If I am a stale leader, revert to follower
+		s.becomeFollower(output.Term)
+		return PeerInfoFail
+	}
+
+	s.raftStateMutex.Lock()
+	defer s.raftStateMutex.Unlock()
+
+	if s.term != leaderTerm || !s.isMember(peerId) {
+		// Replies from removed servers are not counted
+		return PeerInfoFail
+	}
+
+	if output.Term == leaderTerm {
+		// Even a follower with an inconsistent log accepts my leadership
+		s.recordAck(peerId, readRound, sentAt)
+	}
+
+	if !output.Success {
+		// If log inconsistency, skip back past the conflicting entries
+		s.nextIndex[peerId] = s.nextIndexAfterConflict(peerId, output)
+		return PeerInfoFail
+	}
+
+	// Pipelined requests may have moved next index further already
+	s.matchIndex[peerId] = max(s.matchIndex[peerId], output.MatchedIndex)
+	s.nextIndex[peerId] = max(s.nextIndex[peerId], s.matchIndex[peerId]+1)
+	s.advanceCommitIndex()
+
+	return PeerInfoSuccess
+}
+
+// Locked
+// Fast log backtracking: if I have entries of the follower's conflicting
+// term, it matches up to my last one of them, otherwise the follower's
+// whole conflicting term is skipped at once
+func (s *RaftSyncinator) nextIndexAfterConflict(peerId int64, output *AppendEntryOutput) int64 {
+	nextIndex := output.ConflictIndex
+	if output.ConflictTerm != NO_TERM {
+		for i := s.lastLogIndex(); i > s.snapshotIndex; i-- {
+			term := s.logTerm(i)
+			if term == output.ConflictTerm {
+				nextIndex = i + 1
+				break
+			}
+			if term < output.ConflictTerm {
+				break
+			}
+		}
+	}
+	// Entries up to match index are known to be replicated
+	return max(min(nextIndex, s.lastLogIndex()+1), s.matchIndex[peerId]+1, 0)
+}
+
+// Locked
+func (s *RaftSyncinator) advanceCommitIndex() {
+	// If there exists an N such that N > commitIndex, a majority of
+	// matchIndex[i] ≥ N, and log[N].term == currentTerm: set commitIndex = N
+	for N := s.lastLogIndex(); N > s.commitIndex; N-- {
+		if s.logTerm(N) != s.term {
+			// Entries from previous terms are only committed indirectly
+			break
+		}
+		// Only voters of the current configuration count, including myself if I am one
+		numMatched := 0
+		for peerId := range s.peers {
+			if s.learners[peerId] {
+				continue
+			}
+			if peerId == s.id {
+				numMatched++
+			} else if matchIndex, ok := s.matchIndex[peerId]; ok && matchIndex >= N {
+				numMatched++
+			}
+		}
+		if numMatched >= s.m {
+			s.commitIndex = N
+			s.executeStateMachine()
+			break
+		}
+	}
+}
+
+// Locked
+func (s *RaftSyncinator) notifyCommit() {
+	close(s.commitChannel)
+	s.commitChannel = make(chan struct{})
+}
@@ -0,0 +1,87 @@
+package syncinator
+
+import (
+	"log"
+	"sort"
+)
+
+// Each client numbers its updates, and the state machine remembers the
+// response to the latest one, so a retried update is applied only once
+
+// Locked
+// Returns the cached response if the update has already been applied
+func (s *RaftSyncinator) lookupSession(fileMetaData *FileMetaData) (*UpdateFileResponse, bool) {
+	if fileMetaData.ClientId == 0 {
+		// Updates without a client id are never deduplicated
+		return nil, false
+	}
+	session, ok := s.sessions[fileMetaData.ClientId]
+	if !ok || fileMetaData.Sequence > session.Sequence {
+		return nil, false
+	}
+	if fileMetaData.Sequence < session.Sequence {
+		// The client has already moved on to a newer update
+		return &UpdateFileResponse{version: nil, Err: ErrStaleRequest}, true
+	}
+	return &UpdateFileResponse{version: &Version{Version: session.Version}, Err: nil}, true
+}
+
+// Locked
+// Applies the update at logIndex to the meta store unless it is a duplicate
+func (s *RaftSyncinator) applyUpdate(logIndex int64, fileMetaData *FileMetaData) *UpdateFileResponse {
+	if response, ok := s.lookupSession(fileMetaData); ok {
+		return response
+	}
+
+	// Session fields are not part of the stored metadata
+	version, err := s.metaStore.UpdateFile(s.getNewContext(), copyFileInfo(fileMetaData))
+	if err != nil {
+		// Skipping an entry would make this replica diverge from the others
+		log.Fatal("Error During Meta Store Update ", err)
+	}
+	if fileMetaData.ClientId != 0 {
+		s.sessions[fileMetaData.ClientId] = &ClientSession{
+			ClientId:     fileMetaData.ClientId,
+			Sequence:     fileMetaData.Sequence,
+			Version:      version.Version,
+			AppliedIndex: logIndex,
+		}
+		s.evictSessions()
+	}
+	return &UpdateFileResponse{version: version, Err: nil}
+}
+
+// Locked
+// Drops the least recently used session once there are too many. Every
+// server applies the same entries, so they all evict the same session.
+func (s *RaftSyncinator) evictSessions() {
+	if len(s.sessions) <= MAX_CLIENT_SESSIONS {
+		return
+	}
+	var oldest *ClientSession
+	for _, session := range s.sessions {
+		if oldest == nil || session.AppliedIndex < oldest.AppliedIndex {
+			oldest = session
+		}
+	}
+	delete(s.sessions, oldest.ClientId)
+}
+
+// Locked
+func (s *RaftSyncinator) copySessions() []*ClientSession {
+	sessions := make([]*ClientSession, 0, len(s.sessions))
+	for _, session := range s.sessions {
+		sessions = append(sessions, session)
+	}
+	// Keep snapshots deterministic
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ClientId < sessions[j].ClientId })
+	return sessions
+}
+
+// Locked
+func (s *RaftSyncinator) restoreSessions(sessions []*ClientSession) {
+	s.sessions = make(map[int64]*ClientSession, len(sessions))
+	for _, session := range sessions {
+		s.sessions[session.ClientId] = session
+	}
+}
@@ -0,0 +1,379 @@
+package syncinator
+
+import (
+	context "context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Faults a SimNetwork injects into every request and reply
+type SimFaults struct {
+	// Probability that a message is lost
+	DropRate float64
+
+	// Messages are delayed uniformly up to MaxDelay of simulated time
+	MaxDelay time.Duration
+
+	// Probability that a message is held back for another MaxDelay, so
+	// that later messages overtake it
+	ReorderRate float64
+}
+
+// Routes calls between servers of one process on a SimClock. The fate of
+// each message is drawn from the network's seed and the message itself, so it
+// does not depend on the order in which concurrent senders reach the network.
+type SimNetwork struct {
+	clock *SimClock
+	seed  int64
+
+	mutex   sync.Mutex
+	faults  SimFaults
+	servers map[string]*RaftSyncinator
+	groups  map[int64]int
+	// Times each message was sent, as identical messages may be sent again
+	numSent map[uint64]uint64
+	trace   []string
+}
+
+func NewSimNetwork(clock *SimClock, seed int64, faults SimFaults) *SimNetwork {
+	return &SimNetwork{
+		clock:   clock,
+		seed:    seed,
+		faults:  faults,
+		servers: make(map[string]*RaftSyncinator),
+		groups:  make(map[int64]int),
+		numSent: make(map[uint64]uint64),
+	}
+}
+
+// Makes the server reachable at its listen address
+func (n *SimNetwork) Register(server *RaftSyncinator) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.servers[server.listenAddr] = server
+}
+
+func (n *SimNetwork) SetFaults(faults SimFaults) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.faults = faults
+}
+
+// Splits the servers into groups that cannot reach each other, servers
+// left out form one more group
+func (n *SimNetwork) Partition(groups ...[]int64) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.groups = make(map[int64]int)
+	for i, group := range groups {
+		for _, id := range group {
+			n.groups[id] = i + 1
+		}
+	}
+}
+
+func (n *SimNetwork) Heal() {
+	n.Partition()
+}
+
+// Returns the transport the server with the given id sends through
+func (n *SimNetwork) Transport(fromId int64) RaftTransport {
+	return simTransport{network: n, from: fromId}
+}
+
+// Returns every message delivered or dropped so far, in order
+func (n *SimNetwork) Trace() []string {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return append([]string(nil), n.trace...)
+}
+
+// Decides whether a message gets through and how long it takes. Returns the
+// order in which it arrives, or is found lost, among messages due at the same
+// instant.
+func (n *SimNetwork) transmit(from int64, to int64, message proto.Message) (time.Duration, simTimerOrder, string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d %d %s ", from, to, proto.MessageName(message))
+	h.Write(data)
+	hash := h.Sum64()
+	// A message sent again gets a fate of its own
+	fmt.Fprintf(h, " %d", n.numSent[hash])
+	n.numSent[hash]++
+	id := h.Sum64()
+	order := simTimerOrder{isMessage: true, owner: from, peer: to, seq: id}
+
+	if n.groups[from] != n.groups[to] {
+		return 0, order, SIM_CUT_OFF
+	}
+	source := rand.New(rand.NewSource(n.seed ^ int64(id)))
+	if source.Float64() < n.faults.DropRate {
+		return 0, order, SIM_DROPPED
+	}
+	delay := time.Duration(0)
+	if n.faults.MaxDelay > 0 {
+		delay = time.Duration(source.Int63n(int64(n.faults.MaxDelay) + 1))
+	}
+	if source.Float64() < n.faults.ReorderRate {
+		delay += n.faults.MaxDelay
+	}
+	return delay, order, SIM_DELIVERED
+}
+
+// Waits for delay of simulated time, even if zero, so that messages due at
+// the same instant arrive one at a time
+func (n *SimNetwork) wait(ctx context.Context, delay time.Duration, order simTimerOrder) error {
+	timer := n.clock.addTimer(delay, 0, order)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		return contextError(ctx)
+	}
+}
+
+// Sends a message through the network. Returns ErrMessageDropped if it is lost.
+func (n *SimNetwork) send(ctx context.Context, from int64, to int64, message proto.Message) error {
+	delay, order, fate := n.transmit(from, to, message)
+	if err := n.wait(ctx, delay, order); err != nil {
+		return err
+	}
+
+	n.mutex.Lock()
+	n.trace = append(n.trace, fmt.Sprintf("%s %d->%d %s %016x %s", n.clock.Now().Format("15:04:05.000000"), from, to, proto.MessageName(message).Name(), order.seq, fate))
+	n.mutex.Unlock()
+
+	if fate != SIM_DELIVERED {
+		return ErrMessageDropped
+	}
+	return nil
+}
+
+func (n *SimNetwork) lookup(addr string) (*RaftSyncinator, bool) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	server, ok := n.servers[addr]
+	return server, ok
+}
+
+type simTransport struct {
+	network *SimNetwork
+	from    int64
+}
+
+func (t simTransport) Connect(peerId int64, addr string) (RaftPeerClient, error) {
+	return &simPeerClient{network: t.network, from: t.from, to: peerId, addr: addr}, nil
+}
+
+type simPeerClient struct {
+	network *SimNetwork
+	from    int64
+	to      int64
+	addr    string
+}
+
+// Delivers the request and its reply through the network. Both are copied,
+// as if serialized, so that servers never share messages.
+func simCall[In proto.Message, Out proto.Message](ctx context.Context, c *simPeerClient, input In, handle func(*RaftSyncinator, context.Context, In) (Out, error)) (Out, error) {
+	var none Out
+	server, ok := c.network.lookup(c.addr)
+	if !ok {
+		return none, fmt.Errorf("no server at %s: %w", c.addr, ErrMessageDropped)
+	}
+
+	if err := c.network.send(ctx, c.from, c.to, input); err != nil {
+		return none, err
+	}
+	output, err := handle(server, ctx, proto.Clone(input).(In))
+	if err != nil {
+		return none, err
+	}
+
+	if err := c.network.send(ctx, c.to, c.from, output); err != nil {
+		return none, err
+	}
+	return proto.Clone(output).(Out), nil
+}
+
+func (c *simPeerClient) AppendEntries(ctx context.Context, in *AppendEntryInput, opts ...grpc.CallOption) (*AppendEntryOutput, error) {
+	return simCall(ctx, c, in, (*RaftSyncinator).AppendEntries)
+}
+
+func (c *simPeerClient) RequestVote(ctx context.Context, in *RequestVoteInput, opts ...grpc.CallOption) (*RequestVoteOutput, error) {
+	return simCall(ctx, c, in, (*RaftSyncinator).RequestVote)
+}
+
+func (c *simPeerClient) InstallSnapshot(ctx context.Context, in *InstallSnapshotInput, opts ...grpc.CallOption) (*InstallSnapshotOutput, error) {
+	return simCall(ctx, c, in, (*RaftSyncinator).InstallSnapshot)
+}
+
+func (c *simPeerClient) TimeoutNow(ctx context.Context, in *TimeoutNowInput, opts ...grpc.CallOption) (*Success, error) {
+	return simCall(ctx, c, in, (*RaftSyncinator).TimeoutNow)
+}
+
+func (c *simPeerClient) Close() error {
+	return nil
+}
+
+// A cluster of servers in one process, talking through a SimNetwork on a
+// SimClock. Nothing happens unless the cluster is run. Servers of a
+// finished simulation stay blocked on its clock.
+type SimCluster struct {
+	Clock   *SimClock
+	Network *SimNetwork
+	Servers []*RaftSyncinator
+
+	rand *rand.Rand
+}
+
+// The seed determines every election timeout, every network fault and the
+// random partitions, so runs with the same seed on one thread deliver the same
+// messages in the same order and end with the same logs
+func NewSimCluster(numServers int, seed int64, faults SimFaults) (*SimCluster, error) {
+	clock := NewSimClock()
+	cluster := &SimCluster{
+		Clock:   clock,
+		Network: NewSimNetwork(clock, seed, faults),
+		Servers: make([]*RaftSyncinator, numServers),
+		rand:    rand.New(rand.NewSource(seed)),
+	}
+
+	raftAddrs := make([]string, numServers)
+	for id := range raftAddrs {
+		raftAddrs[id] = fmt.Sprintf("sim:%d", id)
+	}
+	for id := range cluster.Servers {
+		config := RaftConfig{
+			RaftAddrs: raftAddrs,
+			Transport: cluster.Network.Transport(int64(id)),
+			Clock:     clock.For(int64(id)),
+			// Never zero, which would seed from the real clock
+			Seed: cluster.rand.Int63() | 1,
+		}
+		server, err := NewRaftServer(int64(id), config)
+		if err != nil {
+			return nil, err
+		}
+		cluster.Servers[id] = server
+		cluster.Network.Register(server)
+	}
+	for _, server := range cluster.Servers {
+		go server.runElectionTimer()
+	}
+	return cluster, nil
+}
+
+// Advances simulated time by d
+func (c *SimCluster) RunFor(d time.Duration) {
+	c.RunUntil(d, func() bool { return false })
+}
+
+// Advances simulated time until isDone holds or maxDuration has passed.
+// Returns whether isDone holds. isDone is checked whenever the servers are
+// idle after a timer fires, so the run stops at the same instant every time.
+func (c *SimCluster) RunUntil(maxDuration time.Duration, isDone func() bool) bool {
+	waitForIdle()
+	end := c.Clock.Now().Add(maxDuration)
+	for !isDone() {
+		if !c.Clock.step(end) {
+			return isDone()
+		}
+	}
+	return true
+}
+
+// Runs call in the background while advancing simulated time, until it
+// returns or maxDuration has passed. Returns whether it returned.
+func (c *SimCluster) RunWhile(maxDuration time.Duration, call func()) bool {
+	done := make(chan struct{})
+	go func() {
+		call()
+		close(done)
+	}()
+	return c.RunUntil(maxDuration, func() bool {
+		select {
+		case <-done:
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+// Splits the servers into two random sides, either of which may be empty
+func (c *SimCluster) PartitionRandomly() ([]int64, []int64) {
+	side, otherSide := c.randomSides()
+	c.Network.Partition(side, otherSide)
+	return side, otherSide
+}
+
+// Partitions the servers randomly, or heals the network, at times drawn from
+// the seed, about every interval until duration has passed
+func (c *SimCluster) SchedulePartitions(interval time.Duration, duration time.Duration) {
+	clock := c.Clock.For(SIM_SCHEDULE_OWNER)
+	timers := make([]RaftTimer, 0)
+	groups := make([][][]int64, 0)
+	elapsed := interval/2 + time.Duration(c.rand.Int63n(int64(interval)))
+	for ; elapsed <= duration; elapsed += interval/2 + time.Duration(c.rand.Int63n(int64(interval))) {
+		timers = append(timers, clock.NewTimer(elapsed))
+		if c.rand.Intn(2) == 0 {
+			side, otherSide := c.randomSides()
+			groups = append(groups, [][]int64{side, otherSide})
+		} else {
+			groups = append(groups, nil)
+		}
+	}
+
+	go func() {
+		for i, timer := range timers {
+			<-timer.C()
+			c.Network.Partition(groups[i]...)
+		}
+	}()
+}
+
+func (c *SimCluster) randomSides() ([]int64, []int64) {
+	side, otherSide := make([]int64, 0), make([]int64, 0)
+	for id := range c.Servers {
+		if c.rand.Intn(2) == 0 {
+			side = append(side, int64(id))
+		} else {
+			otherSide = append(otherSide, int64(id))
+		}
+	}
+	return side, otherSide
+}
+
+// Restores a crashed server with healthy links, as a Restore call through
+// gRPC would, since the chaos interceptor is not on the simulated path
+func (c *SimCluster) Restore(id int64) {
+	server := c.Servers[id]
+	server.Restore(context.Background(), &emptypb.Empty{})
+	server.clearChaos()
+}
+
+// Returns the leader of the highest term, or NO_LEADER
+func (c *SimCluster) Leader() (int64, int64) {
+	leaderId, leaderTerm := NO_LEADER, NO_TERM
+	for _, server := range c.Servers {
+		server.serverStatusMutex.RLock()
+		server.raftStateMutex.RLock()
+		if server.serverStatus == ServerStatus_LEADER && server.term > leaderTerm {
+			leaderId, leaderTerm = server.id, server.term
+		}
+		server.raftStateMutex.RUnlock()
+		server.serverStatusMutex.RUnlock()
+	}
+	return leaderId, leaderTerm
+}
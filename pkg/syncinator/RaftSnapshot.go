@@ -0,0 +1,54 @@
+package syncinator
+
+import (
+	"log"
+)
+
+// Locked
+func (s *RaftSyncinator) maybeTakeSnapshot() {
+	if s.lastApplied-s.snapshotIndex < s.snapshotThreshold {
+		return
+	}
+
+	fileInfoMap, err := s.metaStore.Storage.GetAll(s.getNewContext())
+	if err != nil {
+		log.Fatal("Error During Meta Store Snapshot ", err)
+	}
+	configuration, _ := s.configurationAt(s.lastApplied)
+	snapshot := &RaftSnapshot{
+		LastIncludedIndex: s.lastApplied,
+		LastIncludedTerm:  s.logTerm(s.lastApplied),
+		MetaMap:           &FileInfoMap{FileInfoMap: fileInfoMap},
+		Configuration:     configuration,
+		Sessions:          s.copySessions(),
+	}
+	s.compactLog(snapshot)
+
+	log.Printf("Server %d took snapshot at index %d", s.id, snapshot.LastIncludedIndex)
+}
+
+// Locked
+// Replaces the log prefix covered by the snapshot, retaining the entries that follow it
+func (s *RaftSyncinator) compactLog(snapshot *RaftSnapshot) {
+	retained := make([]*UpdateOperation, 0)
+	lastIncludedIndex := snapshot.LastIncludedIndex
+	if lastIncludedIndex < s.lastLogIndex() && s.logTerm(lastIncludedIndex) == snapshot.LastIncludedTerm {
+		retained = append(retained, s.logFrom(lastIncludedIndex+1)...)
+	}
+
+	s.snapshot = snapshot
+	s.snapshotIndex = lastIncludedIndex
+	s.snapshotTerm = snapshot.LastIncludedTerm
+	s.log = retained
+	s.persistSnapshot()
+	s.refreshConfiguration()
+}
+
+// Locked
+func (s *RaftSyncinator) restoreStateMachine(snapshot *RaftSnapshot) {
+	if err := s.metaStore.Storage.Replace(s.getNewContext(), snapshot.MetaMap.GetFileInfoMap()); err != nil {
+		log.Fatal("Error During Meta Store Restore ", err)
+	}
+	s.restoreSessions(snapshot.Sessions)
+	s.lastApplied = snapshot.LastIncludedIndex
+}
@@ -3,7 +3,9 @@ package syncinator
 import (
 	context "context"
 	"log"
+	"math/rand"
 	"sync"
+	"time"
 
 	grpc "google.golang.org/grpc"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
@@ -21,22 +23,67 @@ type RaftSyncinator struct {
 	commitIndex    int64
 	raftStateMutex *sync.RWMutex
 
-	rpcConns   []*grpc.ClientConn
-	grpcServer *grpc.Server
+	peerClients map[int64]RaftPeerClient
+	transport   RaftTransport
+	grpcServer  *grpc.Server
 
 	/*--------------- Added --------------*/
 	n int
 	m int
 
-	lastApplied      int64
-	nextIndex        []int64
-	matchIndex       []int64
-	pendingResponses map[int64]*UpdateFileResponse
-
-	peers []string
+	lastApplied   int64
+	nextIndex     map[int64]int64
+	matchIndex    map[int64]int64
+	commitWaiters map[int64]*commitWaiter
+
+	peers map[int64]string
+
+	/*--------------- Replication --------------*/
+	replicationTriggers map[int64]chan struct{}
+	commitChannel       chan struct{}
+
+	/*--------------- Persistence --------------*/
+	persister *RaftPersister
+
+	/*--------------- Sessions --------------*/
+	sessions map[int64]*ClientSession
+
+	/*--------------- Snapshot --------------*/
+	snapshot          *RaftSnapshot
+	snapshotIndex     int64
+	snapshotTerm      int64
+	snapshotThreshold int64
+
+	/*--------------- Membership --------------*/
+	configuration        *RaftConfiguration
+	configurationIndex   int64
+	learners             map[int64]bool
+	initialConfiguration *RaftConfiguration
+	listenAddr           string
+
+	/*--------------- Election --------------*/
+	votedFor        int64
+	leaderId        int64
+	transferTarget  int64
+	lastContact     time.Time
+	leaderContact   time.Time
+	leaderSince     time.Time
+	electionTimeout time.Duration
+	clock           RaftClock
+	rand            *rand.Rand
+
+	/*--------------- Read --------------*/
+	readRound  int64
+	ackedRound map[int64]int64
+	ackedAt    map[int64]time.Time
+	ackChannel chan struct{}
+	leaseReads bool
 
 	/*--------------- Chaos Monkey --------------*/
 	unreachableFrom map[int64]bool
+	linkFaults      map[int64]*LinkFault
+	clientFault     *LinkFault
+	pauseChannel    chan struct{}
 	UnimplementedRaftSyncinatorServer
 }
 
@@ -48,14 +95,14 @@ func (s *RaftSyncinator) GetFileInfoMap(ctx context.Context, empty *emptypb.Empt
 		return nil, err
 	}
 
-	// Wait for majority, ensure the meta store is up-to-date
-	success := s.sendPersistentHeartbeats()
-	if !success {
-		// Reverted to follower
-		return nil, ErrNotLeader
+	// Confirm leadership, ensure the meta store is up-to-date
+	if err := s.waitForReadIndex(ctx); err != nil {
+		return nil, err
 	}
 
-	return s.metaStore.GetFileInfoMap(s.getNewContext(), empty)
+	s.raftStateMutex.RLock()
+	defer s.raftStateMutex.RUnlock()
+	return s.metaStore.GetFileInfoMap(ctx, empty)
 }
 
 func (s *RaftSyncinator) GetBlockStoreMap(ctx context.Context, hashes *BlockHashes) (*BlockStoreMap, error) {
@@ -66,14 +113,14 @@ func (s *RaftSyncinator) GetBlockStoreMap(ctx context.Context, hashes *BlockHash
 		return nil, err
 	}
 
-	// Wait for majority, ensure the meta store is up-to-date
-	success := s.sendPersistentHeartbeats()
-	if !success {
-		// Reverted to follower
-		return nil, ErrNotLeader
+	// Confirm leadership, ensure the meta store is up-to-date
+	if err := s.waitForReadIndex(ctx); err != nil {
+		return nil, err
 	}
 
-	return s.metaStore.GetBlockStoreMap(s.getNewContext(), hashes)
+	s.raftStateMutex.RLock()
+	defer s.raftStateMutex.RUnlock()
+	return s.metaStore.GetBlockStoreMap(ctx, hashes)
 
 }
 
@@ -85,15 +132,44 @@ func (s *RaftSyncinator) GetBlockStoreAddrs(ctx context.Context, empty *emptypb.
 		return nil, err
 	}
 
-	// Wait for majority, ensure the meta store is up-to-date
-	success := s.sendPersistentHeartbeats()
-	if !success {
-		// Reverted to follower
-		return nil, ErrNotLeader
+	// Confirm leadership, ensure the meta store is up-to-date
+	if err := s.waitForReadIndex(ctx); err != nil {
+		return nil, err
+	}
+
+	s.raftStateMutex.RLock()
+	defer s.raftStateMutex.RUnlock()
+	return s.metaStore.GetBlockStoreAddrs(ctx, empty)
+
+}
+
+func (s *RaftSyncinator) GetReplicaFileInfoMap(ctx context.Context, input *ReplicaReadInput) (*ReplicaFileInfoMap, error) {
+	// Serve from my applied meta store, which may be behind the leader
+
+	// Check status
+	s.serverStatusMutex.RLock()
+	myStatus := s.serverStatus
+	s.serverStatusMutex.RUnlock()
+	if myStatus == ServerStatus_CRASHED {
+		return nil, ErrServerCrashed
 	}
 
-	return s.metaStore.GetBlockStoreAddrs(s.getNewContext(), empty)
+	// Wait until the requested index is applied
+	if err := s.waitForReplicaIndex(ctx, myStatus, input.MinIndex); err != nil {
+		return nil, err
+	}
 
+	s.raftStateMutex.RLock()
+	defer s.raftStateMutex.RUnlock()
+	fileInfoMap, err := s.metaStore.GetFileInfoMap(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return &ReplicaFileInfoMap{
+		FileInfoMap:     fileInfoMap,
+		LastApplied:     s.lastApplied,
+		LastAppliedTerm: s.logTerm(s.lastApplied),
+	}, nil
 }
 
 func (s *RaftSyncinator) UpdateFile(ctx context.Context, filemeta *FileMetaData) (*Version, error) {
@@ -105,29 +181,44 @@ func (s *RaftSyncinator) UpdateFile(ctx context.Context, filemeta *FileMetaData)
 		return nil, err
 	}
 
+	// An entry of my term appended after I stepped down would break log matching
+	if err := s.lockAsLeader(); err != nil {
+		return nil, err
+	}
+	// Hold off updates while the target catches up
+	if s.transferTarget != NO_LEADER {
+		s.raftStateMutex.Unlock()
+		return nil, ErrTransferInProgress
+	}
+
+	// Reply from the session if the update is a retry of an applied one
+	if response, ok := s.lookupSession(filemeta); ok {
+		s.raftStateMutex.Unlock()
+		return response.version, response.Err
+	}
+
 	// Append to log
-	s.raftStateMutex.Lock()
 	entry := &UpdateOperation{
 		Term:         s.term,
 		FileMetaData: filemeta,
 	}
 	s.log = append(s.log, entry)
-	requestLogIndex := int64(len(s.log) - 1)
+	requestLogIndex := s.lastLogIndex()
+	s.persistLog(requestLogIndex)
+	waiter := s.registerCommitWaiter(requestLogIndex, entry.Term)
+	// Replicate in the background
+	s.triggerReplication()
+	s.advanceCommitIndex()
 	s.raftStateMutex.Unlock()
 
-	// Wait for majority, commit logs and apply to state machine
-	success := s.sendPersistentHeartbeats()
-	if !success {
-		// Reverted to follower
-		return nil, ErrNotLeader
+	// Wait until committed and applied to state machine. If the client gives
+	// up first, the entry may still commit, and a retry is deduplicated.
+	response, err := s.waitForCommit(ctx, requestLogIndex, waiter)
+	if err != nil {
+		// Not committed, leadership lost or request cancelled
+		return nil, err
 	}
 
-	s.raftStateMutex.Lock()
-	// Get response
-	response := s.pendingResponses[requestLogIndex]
-	delete(s.pendingResponses, requestLogIndex)
-	s.raftStateMutex.Unlock()
-
 	return response.version, response.Err
 }
 
@@ -146,36 +237,27 @@ func (s *RaftSyncinator) AppendEntries(ctx context.Context, input *AppendEntryIn
 		return nil, err
 	}
 
-	s.raftStateMutex.RLock()
-	myTerm := s.term
 	myId := s.id
-	s.raftStateMutex.RUnlock()
 
 	// Reject if peer is stale
-	if input.Term < myTerm {
+	myTerm, isCurrentLeader := s.acceptLeaderTerm(myStatus, input.Term)
+	if !isCurrentLeader {
 		return s.makeAppendEntryOutput(myTerm, myId, false, -1), nil
 	}
 
-	// Revert to follower if I am stale
-	if myTerm < input.Term {
-		if myStatus != ServerStatus_FOLLOWER {
-			s.serverStatusMutex.Lock()
-			s.serverStatus = ServerStatus_FOLLOWER
-			s.serverStatusMutex.Unlock()
-		}
-
-		s.raftStateMutex.Lock()
-		s.term = input.Term
-		s.raftStateMutex.Unlock()
-		myTerm = input.Term
-	}
-
 	s.raftStateMutex.Lock()
 
-	// Reply false if no matched index
+	// Heard from the current leader
+	s.resetElectionTimer()
+	s.leaderContact = s.lastContact
+	s.leaderId = input.LeaderId
+
+	// Reply false if no matched index, with where my log starts to differ
 	if !s.isPrevLogMatched(input.PrevLogIndex, input.PrevLogTerm) {
+		output := s.makeAppendEntryOutput(myTerm, myId, false, -1)
+		output.ConflictIndex, output.ConflictTerm = s.findConflict(input.PrevLogIndex)
 		s.raftStateMutex.Unlock()
-		return s.makeAppendEntryOutput(myTerm, myId, false, -1), nil
+		return output, nil
 	}
 
 	// Replicate log
@@ -184,17 +266,68 @@ func (s *RaftSyncinator) AppendEntries(ctx context.Context, input *AppendEntryIn
 
 	// Update commit index
 	if input.LeaderCommit > s.commitIndex {
-		s.commitIndex = min(input.LeaderCommit, int64(len(s.log)-1))
+		s.commitIndex = max(s.commitIndex, min(input.LeaderCommit, matchedIndex))
 	}
 
 	// Apply to state machine
-	s.executeStateMachine(false)
+	s.executeStateMachine()
 
 	s.raftStateMutex.Unlock()
 
+	// New entries may have changed my role
+	s.updateLearnerStatus()
+
 	return s.makeAppendEntryOutput(myTerm, myId, true, matchedIndex), nil
 }
 
+// 1. Reply immediately if term < currentTerm
+// 2. If existing log entry has same index and term as snapshot’s last
+// included entry, retain log entries following it and reply
+// 3. Discard the entire log
+// 4. Reset state machine using snapshot contents
+func (s *RaftSyncinator) InstallSnapshot(ctx context.Context, input *InstallSnapshotInput) (*InstallSnapshotOutput, error) {
+	// Check status
+	myStatus, err := s.checkStatus(true, input.LeaderId)
+	if err != nil {
+		return nil, err
+	}
+	myId := s.id
+
+	// Reject if peer is stale
+	myTerm, isCurrentLeader := s.acceptLeaderTerm(myStatus, input.Term)
+	if !isCurrentLeader {
+		return &InstallSnapshotOutput{ServerId: myId, Term: myTerm}, nil
+	}
+
+	// The snapshot may have changed my role
+	defer s.updateLearnerStatus()
+
+	s.raftStateMutex.Lock()
+	defer s.raftStateMutex.Unlock()
+
+	// Heard from the current leader
+	s.resetElectionTimer()
+	s.leaderContact = s.lastContact
+	s.leaderId = input.LeaderId
+
+	snapshot := input.Snapshot
+	if snapshot.LastIncludedIndex <= s.snapshotIndex {
+		// Already have a newer snapshot
+		return &InstallSnapshotOutput{ServerId: myId, Term: myTerm}, nil
+	}
+
+	s.compactLog(snapshot)
+	if s.lastApplied < snapshot.LastIncludedIndex {
+		s.restoreStateMachine(snapshot)
+	}
+	s.commitIndex = max(s.commitIndex, snapshot.LastIncludedIndex)
+
+	// Apply retained entries that are already committed
+	s.executeStateMachine()
+
+	return &InstallSnapshotOutput{ServerId: myId, Term: myTerm}, nil
+}
+
 func (s *RaftSyncinator) SetLeader(ctx context.Context, _ *emptypb.Empty) (*Success, error) {
 	// Check status
 	s.serverStatusMutex.RLock()
@@ -204,27 +337,100 @@ func (s *RaftSyncinator) SetLeader(ctx context.Context, _ *emptypb.Empty) (*Succ
 		return &Success{Flag: false}, ErrServerCrashed
 	}
 
-	s.serverStatusMutex.Lock()
-	s.serverStatus = ServerStatus_LEADER
-	s.serverStatusMutex.Unlock()
+	s.raftStateMutex.RLock()
+	isVoter := s.isVoter(s.id)
+	s.raftStateMutex.RUnlock()
+	if !isVoter {
+		// Learners never lead
+		return &Success{Flag: false}, ErrNotLeader
+	}
 
-	s.raftStateMutex.Lock()
-	s.term++
-	s.initLeaderStates()
-	// Append no-op entry
-	s.log = append(s.log, &UpdateOperation{Term: s.term, FileMetaData: nil})
-	s.raftStateMutex.Unlock()
+	// Run an election right away as if my election timer fired, so the
+	// pre-vote fails while a leader is alive and no term is bumped in vain.
+	// A leader that just crashed is still thought alive for a while, so
+	// retry until it would have timed out anyway.
+	if err := s.campaignUntil(ctx, SET_LEADER_TIMEOUT); err != nil {
+		return &Success{Flag: false}, err
+	}
 
 	// Wait for majority
-	success := s.sendPersistentHeartbeats()
-	if !success {
-		// Reverted to follower
-		return &Success{Flag: false}, ErrNotLeader
+	if err := s.sendPersistentHeartbeats(ctx); err != nil {
+		// Reverted to follower or request cancelled
+		return &Success{Flag: false}, err
 	}
 
 	return &Success{Flag: true}, nil
 }
 
+// 1. Reply false if term < currentTerm (§5.1)
+// 2. If votedFor is null or candidateId, and candidate’s log is at
+// least as up-to-date as receiver’s log, grant vote (§5.2, §5.4)
+func (s *RaftSyncinator) RequestVote(ctx context.Context, input *RequestVoteInput) (*RequestVoteOutput, error) {
+	// Check status
+	myStatus, err := s.checkStatus(true, input.CandidateId)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.PreVote {
+		return s.handlePreVote(myStatus, input), nil
+	}
+
+	// Ignore the candidate if the leader is alive, so its lease stays valid,
+	// unless the leader itself handed over leadership
+	s.raftStateMutex.RLock()
+	isLeaderAlive := s.isLeaderAlive() && !input.LeadershipTransfer
+	myTerm := s.term
+	s.raftStateMutex.RUnlock()
+	if isLeaderAlive {
+		return s.makeRequestVoteOutput(myTerm, s.id, false), nil
+	}
+
+	// Revert to follower if I am stale
+	s.becomeFollower(input.Term)
+
+	s.raftStateMutex.Lock()
+	defer s.raftStateMutex.Unlock()
+
+	// Learners never vote
+	if !s.isVoter(s.id) {
+		return s.makeRequestVoteOutput(s.term, s.id, false), nil
+	}
+
+	// Reject if candidate is stale
+	if input.Term < s.term {
+		return s.makeRequestVoteOutput(s.term, s.id, false), nil
+	}
+
+	// Reject if already voted for another candidate or candidate log is behind
+	if s.votedFor != NO_VOTE && s.votedFor != input.CandidateId {
+		return s.makeRequestVoteOutput(s.term, s.id, false), nil
+	}
+	if !s.isLogUpToDate(input.LastLogIndex, input.LastLogTerm) {
+		return s.makeRequestVoteOutput(s.term, s.id, false), nil
+	}
+
+	s.votedFor = input.CandidateId
+	s.persistState()
+	s.resetElectionTimer()
+	return s.makeRequestVoteOutput(s.term, s.id, true), nil
+}
+
+// Grants a pre-vote if a real vote would be granted and no leader is alive,
+// without changing my term or vote
+func (s *RaftSyncinator) handlePreVote(myStatus ServerStatus, input *RequestVoteInput) *RequestVoteOutput {
+	s.raftStateMutex.RLock()
+	defer s.raftStateMutex.RUnlock()
+
+	if myStatus == ServerStatus_LEADER || !s.isVoter(s.id) || s.isLeaderAlive() {
+		return s.makeRequestVoteOutput(s.term, s.id, false)
+	}
+	if input.Term <= s.term || !s.isLogUpToDate(input.LastLogIndex, input.LastLogTerm) {
+		return s.makeRequestVoteOutput(s.term, s.id, false)
+	}
+	return s.makeRequestVoteOutput(s.term, s.id, true)
+}
+
 func (s *RaftSyncinator) SendHeartbeat(ctx context.Context, _ *emptypb.Empty) (*Success, error) {
 	// Check status
 	if _, err := s.checkStatus(false, -1); err != nil {
@@ -232,10 +438,9 @@ func (s *RaftSyncinator) SendHeartbeat(ctx context.Context, _ *emptypb.Empty) (*
 	}
 
 	// Wait for majority
-	success := s.sendPersistentHeartbeats()
-	if !success {
-		// Reverted to follower
-		return &Success{Flag: false}, ErrNotLeader
+	if err := s.sendPersistentHeartbeats(ctx); err != nil {
+		// Reverted to follower or request cancelled
+		return &Success{Flag: false}, err
 	}
 
 	return &Success{Flag: true}, nil
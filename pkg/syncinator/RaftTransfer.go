@@ -0,0 +1,99 @@
+package syncinator
+
+import (
+	context "context"
+	"log"
+)
+
+// Leadership transfer (§3.10 of the Raft dissertation):
+// 1. Stop accepting client updates
+// 2. Replicate the whole log to the target
+// 3. Send TimeoutNow so the target starts an election right away
+// 4. Step down once the target's term is seen
+
+func (s *RaftSyncinator) TransferLeadership(ctx context.Context, input *TransferLeadershipInput) (*Success, error) {
+	// Check status
+	if _, err := s.checkStatus(false, -1); err != nil {
+		return &Success{Flag: false}, err
+	}
+
+	deadline := s.clock.NewTimer(TRANSFER_TIMEOUT)
+	defer deadline.Stop()
+
+	s.raftStateMutex.Lock()
+	defer s.raftStateMutex.Unlock()
+
+	if input.TargetId == s.id {
+		return &Success{Flag: true}, nil
+	}
+	if !s.isVoter(input.TargetId) {
+		return &Success{Flag: false}, ErrInvalidTransferTarget
+	}
+	if s.transferTarget != NO_LEADER {
+		return &Success{Flag: false}, ErrTransferInProgress
+	}
+	leaderTerm := s.term
+	s.transferTarget = input.TargetId
+	defer func() {
+		s.transferTarget = NO_LEADER
+	}()
+
+	// Bring the target up-to-date, no new entries are appended meanwhile
+	s.triggerReplication()
+	for s.matchIndex[input.TargetId] < s.lastLogIndex() {
+		if err := s.waitLocked(ctx, s.ackChannel, deadline.C(), leaderTerm); err != nil {
+			return &Success{Flag: false}, err
+		}
+	}
+
+	timeoutNowInput := &TimeoutNowInput{
+		Term:     leaderTerm,
+		LeaderId: s.id,
+	}
+	client := s.getPeerClient(input.TargetId)
+	s.raftStateMutex.Unlock()
+
+	log.Printf("Server %d transfers leadership of term %d to server %d", s.id, leaderTerm, input.TargetId)
+	rpcCtx, cancel := s.clock.WithTimeout(ctx, RPC_TIMEOUT)
+	_, err := client.TimeoutNow(rpcCtx, timeoutNowInput)
+	cancel()
+
+	s.raftStateMutex.Lock()
+	if err != nil {
+		return &Success{Flag: false}, err
+	}
+
+	// Wait until the target's election deposes me
+	for s.term == leaderTerm {
+		if err := s.waitLocked(ctx, s.ackChannel, deadline.C(), leaderTerm); err == ErrNotLeader {
+			break
+		} else if err != nil {
+			return &Success{Flag: false}, err
+		}
+	}
+	return &Success{Flag: true}, nil
+}
+
+// Starts an election immediately, ignoring leader stickiness of the voters
+func (s *RaftSyncinator) TimeoutNow(ctx context.Context, input *TimeoutNowInput) (*Success, error) {
+	// Check status
+	myStatus, err := s.checkStatus(true, input.LeaderId)
+	if err != nil {
+		return &Success{Flag: false}, err
+	}
+
+	// Reject if peer is stale
+	if _, isCurrentLeader := s.acceptLeaderTerm(myStatus, input.Term); !isCurrentLeader {
+		return &Success{Flag: false}, nil
+	}
+
+	s.raftStateMutex.RLock()
+	isVoter := s.isVoter(s.id)
+	s.raftStateMutex.RUnlock()
+	if !isVoter {
+		return &Success{Flag: false}, ErrInvalidTransferTarget
+	}
+
+	go s.startElection(true)
+	return &Success{Flag: true}, nil
+}
@@ -0,0 +1,43 @@
+package syncinator
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// The RPCs servers make on each other. Close releases the connection once the
+// peer leaves the configuration.
+type RaftPeerClient interface {
+	AppendEntries(ctx context.Context, in *AppendEntryInput, opts ...grpc.CallOption) (*AppendEntryOutput, error)
+	RequestVote(ctx context.Context, in *RequestVoteInput, opts ...grpc.CallOption) (*RequestVoteOutput, error)
+	InstallSnapshot(ctx context.Context, in *InstallSnapshotInput, opts ...grpc.CallOption) (*InstallSnapshotOutput, error)
+	TimeoutNow(ctx context.Context, in *TimeoutNowInput, opts ...grpc.CallOption) (*Success, error)
+	Close() error
+}
+
+// Connects a server to its peers. Servers dial each other over gRPC unless
+// RaftConfig.Transport is set, e.g. to a SimNetwork.
+type RaftTransport interface {
+	Connect(peerId int64, addr string) (RaftPeerClient, error)
+}
+
+type grpcTransport struct{}
+
+func (grpcTransport) Connect(peerId int64, addr string) (RaftPeerClient, error) {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &grpcPeerClient{RaftSyncinatorClient: NewRaftSyncinatorClient(conn), conn: conn}, nil
+}
+
+type grpcPeerClient struct {
+	RaftSyncinatorClient
+	conn *grpc.ClientConn
+}
+
+func (c *grpcPeerClient) Close() error {
+	return c.conn.Close()
+}
@@ -4,22 +4,56 @@ import (
 	"bufio"
 	context "context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math/rand"
 	"net"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
 	grpc "google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type RaftConfig struct {
 	RaftAddrs  []string
 	BlockAddrs []string
+
+	// Directory for durable Raft state, kept in memory only if empty
+	DataDir string
+
+	// Number of applied entries between snapshots, DEFAULT_SNAPSHOT_THRESHOLD if zero
+	SnapshotThreshold int64
+
+	// Serve reads under a leader lease instead of a heartbeat round per read
+	LeaseReads bool
+
+	// Address to listen on when joining a running cluster, the server then
+	// starts outside of the configuration until the leader adds it
+	JoinAddr string
+
+	// Ids of the servers in RaftAddrs that start as learners, which receive
+	// the log but neither vote nor count toward the majority
+	Learners []int64
+
+	// Connects to peers, over gRPC if nil
+	Transport RaftTransport `json:"-"`
+
+	// Drives timeouts and heartbeats, the real clock if nil
+	Clock RaftClock `json:"-"`
+
+	// Seeds the election timeouts, from the real clock if zero
+	Seed int64 `json:"-"`
+
+	// Storage of the replicated file metadata, "memory" if empty or "sqlite"
+	// for a database next to the durable Raft state in DataDir
+	MetaStorage string
 }
 
 func LoadRaftConfigFile(filename string) (cfg RaftConfig) {
@@ -41,18 +75,24 @@ func LoadRaftConfigFile(filename string) (cfg RaftConfig) {
 }
 
 func NewRaftServer(id int64, config RaftConfig) (*RaftSyncinator, error) {
-	conns := make([]*grpc.ClientConn, 0)
-	for _, addr := range config.RaftAddrs {
-		conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-		if err != nil {
-			return nil, err
-		}
-		conns = append(conns, conn)
+	listenAddr := config.JoinAddr
+	if listenAddr == "" {
+		listenAddr = config.RaftAddrs[id]
 	}
 
 	serverStatusMutex := sync.RWMutex{}
 	raftStateMutex := sync.RWMutex{}
 
+	switch config.MetaStorage {
+	case "", META_STORAGE_MEMORY:
+	case META_STORAGE_SQLITE:
+		if config.DataDir == "" {
+			return nil, fmt.Errorf("%w: %s needs a DataDir", ErrInvalidMetaStorage, config.MetaStorage)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMetaStorage, config.MetaStorage)
+	}
+
 	// Initialize server
 	server := RaftSyncinator{
 		serverStatus:      ServerStatus_FOLLOWER,
@@ -66,47 +106,110 @@ func NewRaftServer(id int64, config RaftConfig) (*RaftSyncinator, error) {
 		commitIndex:    -1,
 		raftStateMutex: &raftStateMutex,
 
-		rpcConns:   conns,
-		grpcServer: grpc.NewServer(),
+		peerClients: make(map[int64]RaftPeerClient),
+		transport:   config.Transport,
+		clock:       config.Clock,
 
 		/*--------------- Added --------------*/
-		n: len(config.RaftAddrs),
-		m: len(config.RaftAddrs)/2 + 1,
+		lastApplied:   -1,
+		nextIndex:     make(map[int64]int64),
+		matchIndex:    make(map[int64]int64),
+		commitWaiters: make(map[int64]*commitWaiter),
+
+		sessions: make(map[int64]*ClientSession),
+
+		commitChannel: make(chan struct{}),
+
+		snapshotIndex:     -1,
+		snapshotThreshold: config.SnapshotThreshold,
+
+		initialConfiguration: makeInitialConfiguration(config),
+		listenAddr:           listenAddr,
 
-		lastApplied:      -1,
-		nextIndex:        make([]int64, len(config.RaftAddrs)),
-		matchIndex:       make([]int64, len(config.RaftAddrs)),
-		pendingResponses: make(map[int64]*UpdateFileResponse),
+		votedFor: NO_VOTE,
+		leaderId: NO_LEADER,
 
-		peers: config.RaftAddrs,
+		transferTarget: NO_LEADER,
+
+		ackedRound: make(map[int64]int64),
+		ackedAt:    make(map[int64]time.Time),
+		ackChannel: make(chan struct{}),
+		leaseReads: config.LeaseReads,
 
 		unreachableFrom: make(map[int64]bool),
+		linkFaults:      make(map[int64]*LinkFault),
+	}
+	if server.transport == nil {
+		server.transport = grpcTransport{}
+	}
+	if server.clock == nil {
+		server.clock = realClock{}
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	server.rand = rand.New(rand.NewSource(seed))
+	server.grpcServer = grpc.NewServer(grpc.ChainUnaryInterceptor(server.chaosInterceptor, server.leaderHintInterceptor))
+	server.resetElectionTimer()
+	if server.snapshotThreshold <= 0 {
+		server.snapshotThreshold = DEFAULT_SNAPSHOT_THRESHOLD
+	}
+
+	// Replay durable state
+	if config.DataDir != "" {
+		dir := filepath.Join(config.DataDir, fmt.Sprintf("raft%d", id))
+		persister, err := NewRaftPersister(dir)
+		if err != nil {
+			return nil, err
+		}
+		if config.MetaStorage == META_STORAGE_SQLITE {
+			storage, err := NewSQLiteMetaStorage(filepath.Join(dir, RAFT_META_DB_FILENAME))
+			if err != nil {
+				return nil, err
+			}
+			server.metaStore.Storage = storage
+		}
+		term, votedFor, snapshot, entries, err := persister.Load()
+		if err != nil {
+			return nil, err
+		}
+		server.persister = persister
+		server.term = term
+		server.votedFor = votedFor
+		// The log is applied again on top of the snapshot, over whatever the storage held
+		if snapshot != nil {
+			server.snapshot = snapshot
+			server.snapshotIndex = snapshot.LastIncludedIndex
+			server.snapshotTerm = snapshot.LastIncludedTerm
+			server.restoreStateMachine(snapshot)
+			server.commitIndex = snapshot.LastIncludedIndex
+		} else if err := server.metaStore.Storage.Replace(server.getNewContext(), nil); err != nil {
+			return nil, err
+		}
+		server.log = entries
+		log.Printf("Server %d recovered term %d with snapshot at %d and %d log entries", id, term, server.snapshotIndex, len(entries))
 	}
 
+	// Connect to the members of the latest configuration
+	server.applyConfiguration(server.configurationAt(server.lastLogIndex()))
+	server.serverStatus = server.followerStatus()
+
 	return &server, nil
 }
 
 func ServeRaftServer(server *RaftSyncinator) error {
 	RegisterRaftSyncinatorServer(server.grpcServer, server)
-	l, e := net.Listen("tcp", server.peers[server.id])
+	l, e := net.Listen("tcp", server.listenAddr)
 	if e != nil {
 		return e
 	}
-	// Default the last server to leader
-	if server.id == int64(server.n-1) {
-		go server.setInitialLeader()
-	}
-	fmt.Printf("Server %d started at %s\n", server.id, server.peers[server.id])
+	go server.runElectionTimer()
+	fmt.Printf("Server %d started at %s\n", server.id, server.listenAddr)
 	err := server.grpcServer.Serve(l)
 	return err
 }
 
-func (s *RaftSyncinator) setInitialLeader() {
-	time.Sleep(200 * time.Millisecond)
-	s.SetLeader(context.Background(), &emptypb.Empty{})
-	s.SendHeartbeat(context.Background(), &emptypb.Empty{})
-}
-
 func (s *RaftSyncinator) checkStatus(isFromLeader bool, leaderId int64) (ServerStatus, error) {
 	s.serverStatusMutex.RLock()
 	myStatus := s.serverStatus
@@ -135,6 +238,100 @@ func (s *RaftSyncinator) checkStatus(isFromLeader bool, leaderId int64) (ServerS
 	return myStatus, nil
 }
 
+// Takes the state lock for a client request, checking my leadership again
+// under it, as I may have stepped down since checkStatus. Returns with the
+// state lock held only if I am still the leader.
+func (s *RaftSyncinator) lockAsLeader() error {
+	s.serverStatusMutex.RLock()
+	defer s.serverStatusMutex.RUnlock()
+	s.raftStateMutex.Lock()
+	if s.serverStatus != ServerStatus_LEADER || s.leaderId != s.id {
+		s.raftStateMutex.Unlock()
+		return ErrNotLeader
+	}
+	return nil
+}
+
+// Attaches the known leader to ErrNotLeader, ErrNotCommitted and ErrCommitUnknown, so clients can redirect in one hop.
+// Crashed servers report Unavailable.
+func (s *RaftSyncinator) leaderHintInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	var code codes.Code
+	switch {
+	case errors.Is(err, ErrServerCrashed), errors.Is(err, ErrServerCrashedUnreachable):
+		// A crashed server knows no leader, clients move on to another one
+		return resp, status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, ErrNotLeader), errors.Is(err, ErrNotCommitted):
+		// Neither request took effect, retrying is safe
+		code = codes.FailedPrecondition
+	case errors.Is(err, ErrCommitUnknown):
+		// Retrying on the new leader is safe, client sessions deduplicate it
+		code = codes.Unavailable
+	default:
+		return resp, err
+	}
+
+	s.raftStateMutex.RLock()
+	leaderHint := &LeaderHint{
+		LeaderId:   s.leaderId,
+		LeaderAddr: s.peers[s.leaderId],
+	}
+	s.raftStateMutex.RUnlock()
+
+	st, detailsErr := status.New(code, err.Error()).WithDetails(leaderHint)
+	if detailsErr != nil {
+		return resp, err
+	}
+	return resp, st.Err()
+}
+
+// Returns the leader attached to a not leader error, or nil if the leader is unknown
+func LeaderHintFromError(err error) *LeaderHint {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	for _, detail := range st.Details() {
+		if leaderHint, ok := detail.(*LeaderHint); ok && leaderHint.LeaderId != NO_LEADER {
+			return leaderHint
+		}
+	}
+	return nil
+}
+
+// Steps down if leaderTerm is newer, and returns my term and whether the leader is current
+func (s *RaftSyncinator) acceptLeaderTerm(myStatus ServerStatus, leaderTerm int64) (int64, bool) {
+	s.raftStateMutex.RLock()
+	myTerm := s.term
+	s.raftStateMutex.RUnlock()
+
+	// Reject if peer is stale
+	if leaderTerm < myTerm {
+		return myTerm, false
+	}
+
+	// Revert to follower if I am stale
+	if myTerm < leaderTerm {
+		s.becomeFollower(leaderTerm)
+	} else if myStatus == ServerStatus_CANDIDATE {
+		// Another candidate has won the election of my term
+		s.serverStatusMutex.Lock()
+		if s.serverStatus == ServerStatus_CANDIDATE {
+			s.serverStatus = ServerStatus_FOLLOWER
+		}
+		s.serverStatusMutex.Unlock()
+	}
+	return leaderTerm, true
+}
+
+func (s *RaftSyncinator) makeRequestVoteOutput(term int64, serverId int64, voteGranted bool) *RequestVoteOutput {
+	return &RequestVoteOutput{
+		Term:        term,
+		ServerId:    serverId,
+		VoteGranted: voteGranted,
+	}
+}
+
 func (s *RaftSyncinator) makeAppendEntryOutput(term int64, serverId int64, success bool, matchedIndex int64) *AppendEntryOutput {
 	return &AppendEntryOutput{
 		Term:         term,
@@ -144,33 +341,106 @@ func (s *RaftSyncinator) makeAppendEntryOutput(term int64, serverId int64, succe
 	}
 }
 
+// Log indices are absolute, entries up to snapshotIndex only exist in the snapshot
+
+// Locked
+func (s *RaftSyncinator) lastLogIndex() int64 {
+	return s.snapshotIndex + int64(len(s.log))
+}
+
+// Locked
+func (s *RaftSyncinator) logTerm(index int64) int64 {
+	if index < 0 {
+		return 0
+	}
+	if index == s.snapshotIndex {
+		return s.snapshotTerm
+	}
+	return s.logEntry(index).Term
+}
+
+// Locked
+func (s *RaftSyncinator) logEntry(index int64) *UpdateOperation {
+	return s.log[index-s.snapshotIndex-1]
+}
+
+// Locked
+func (s *RaftSyncinator) logFrom(index int64) []*UpdateOperation {
+	return s.log[index-s.snapshotIndex-1:]
+}
+
+// Locked
+// Returns the entries from index on, at most MAX_APPEND_ENTRIES_BATCH of them
+// and MAX_APPEND_ENTRIES_BYTES in total, but always at least one
+func (s *RaftSyncinator) logBatchFrom(index int64) []*UpdateOperation {
+	entries := s.logFrom(index)
+	batchBytes := 0
+	for i, entry := range entries {
+		batchBytes += proto.Size(entry)
+		if i > 0 && (i == MAX_APPEND_ENTRIES_BATCH || batchBytes > MAX_APPEND_ENTRIES_BYTES) {
+			return entries[:i]
+		}
+	}
+	return entries
+}
+
 // Locked
 func (s *RaftSyncinator) isPrevLogMatched(prevLogIndex int64, prevLogTerm int64) bool {
-	myPrevLogIndex := int64(len(s.log) - 1)
+	myPrevLogIndex := s.lastLogIndex()
 	if myPrevLogIndex < prevLogIndex {
 		return false
 	}
-	if prevLogIndex < 0 {
+	if prevLogIndex < s.snapshotIndex {
+		// Compacted entries are committed, so they match the leader
 		return true
 	}
-	myPrevLogTerm := s.log[prevLogIndex].Term
+	myPrevLogTerm := s.logTerm(prevLogIndex)
 	return myPrevLogTerm == prevLogTerm
 }
 
+// Locked
+// Returns the first index of my term at prevLogIndex, so the leader can skip
+// the whole term at once, or the end of my log if it is shorter
+func (s *RaftSyncinator) findConflict(prevLogIndex int64) (int64, int64) {
+	if s.lastLogIndex() < prevLogIndex {
+		return s.lastLogIndex() + 1, NO_TERM
+	}
+	conflictTerm := s.logTerm(prevLogIndex)
+	conflictIndex := prevLogIndex
+	for conflictIndex-1 > s.snapshotIndex && s.logTerm(conflictIndex-1) == conflictTerm {
+		conflictIndex--
+	}
+	return conflictIndex, conflictTerm
+}
+
 // Locked
 func (s *RaftSyncinator) mergeLog(prevLogIndex int64, newEntries []*UpdateOperation) {
+	// Skip entries already covered by the snapshot
+	if prevLogIndex < s.snapshotIndex {
+		numCompacted := min(s.snapshotIndex-prevLogIndex, int64(len(newEntries)))
+		newEntries = newEntries[numCompacted:]
+		prevLogIndex = s.snapshotIndex
+	}
+
 	nextLogIndex := prevLogIndex + 1
-	myLogLength := int64(len(s.log))
+	myLogLength := s.lastLogIndex() + 1
 	newEntiresLength := int64(len(newEntries))
+	nextLogOffset := nextLogIndex - s.snapshotIndex - 1
 
 	// If new entries are longer, replace
 	if nextLogIndex+newEntiresLength >= myLogLength {
-		s.log = append(s.log[:nextLogIndex], newEntries...)
+		if newEntiresLength == 0 {
+			// Heartbeat at the end of my log
+			return
+		}
+		s.log = append(s.log[:nextLogOffset], newEntries...)
+		s.persistLog(nextLogIndex)
+		s.refreshConfiguration()
 		return
 	}
 
 	// Check if existing entries are equal
-	myEntires := s.log[nextLogIndex : nextLogIndex+newEntiresLength]
+	myEntires := s.log[nextLogOffset : nextLogOffset+newEntiresLength]
 	entriesEqual := true
 	for i := range myEntires {
 		if myEntires[i].Term != newEntries[i].Term {
@@ -185,127 +455,130 @@ func (s *RaftSyncinator) mergeLog(prevLogIndex int64, newEntries []*UpdateOperat
 	}
 
 	// If not equal, replace
-	s.log = append(s.log[:nextLogIndex], newEntries...)
+	s.log = append(s.log[:nextLogOffset], newEntries...)
+	s.persistLog(nextLogIndex)
+	s.refreshConfiguration()
 }
 
 // Locked
 func (s *RaftSyncinator) initLeaderStates() {
 	// Init next index and match index
-	s.nextIndex = make([]int64, s.n)
-	for i := range s.nextIndex {
-		s.nextIndex[i] = int64(len(s.log))
-	}
-	s.matchIndex = make([]int64, s.n)
-	for i := range s.matchIndex {
-		s.matchIndex[i] = -1
+	s.nextIndex = make(map[int64]int64)
+	s.matchIndex = make(map[int64]int64)
+	for _, peerId := range s.getPeerIds() {
+		s.nextIndex[peerId] = s.lastLogIndex() + 1
+		s.matchIndex[peerId] = -1
 	}
 
-	// Init pending responses
-	s.pendingResponses = make(map[int64]*UpdateFileResponse)
+	// Acks from previous terms do not confirm my leadership
+	s.ackedRound = make(map[int64]int64)
+	s.ackedAt = make(map[int64]time.Time)
+	s.leaderSince = s.clock.Now()
 }
 
-func (s *RaftSyncinator) sendPersistentHeartbeats() bool {
+// Replicates to a majority before returning, fails if leadership is lost or ctx is done
+func (s *RaftSyncinator) sendPersistentHeartbeats(ctx context.Context) error {
 	// Get index to commit
 	s.raftStateMutex.RLock()
 	toCommitIndex := s.commitIndex
-	if s.log[len(s.log)-1].Term == s.term {
+	if s.logTerm(s.lastLogIndex()) == s.term {
 		// If the latest log entry is from the current term, try committing it
-		toCommitIndex = int64(len(s.log) - 1)
+		toCommitIndex = s.lastLogIndex()
+	}
+	quorum := s.m
+	isVoter := s.isVoter(s.id)
+	peerClients := make(map[int64]RaftPeerClient)
+	peerIsVoter := make(map[int64]bool)
+	for _, peerId := range s.getPeerIds() {
+		peerClients[peerId] = s.getPeerClient(peerId)
+		peerIsVoter[peerId] = s.isVoter(peerId)
 	}
 	s.raftStateMutex.RUnlock()
 
-	// Each follower can report at most twice, one for unreachable and one for success
-	peerMessageChannel := make(chan *PeerMessage, 2*(s.n-1))
-	for peerId := range s.peers {
-		peerId := int64(peerId)
-		if peerId == s.id {
-			continue
-		}
-		go s.mustSendToFollower(peerId, peerMessageChannel)
+	// Senders stop once I return
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Each follower can report at most twice, one for unreachable and one for success or fail
+	peerMessageChannel := make(chan *PeerMessage, 2*len(peerClients))
+	for peerId, client := range peerClients {
+		go s.mustSendToFollower(ctx, peerId, client, peerMessageChannel)
 	}
 
-	peerStatusTable := make([]*PeerStatus, s.n)
-	for i := range peerStatusTable {
-		if int64(i) == s.id {
-			peerStatusTable[i] = &PeerStatus{
-				isReachable: true,
-				isUpdated:   true,
-			}
-		} else {
-			peerStatusTable[i] = &PeerStatus{
-				isReachable: true,
-				isUpdated:   false,
-			}
+	peerStatusTable := make(map[int64]*PeerStatus)
+	for peerId := range peerClients {
+		peerStatusTable[peerId] = &PeerStatus{
+			isReachable: true,
+			isUpdated:   false,
 		}
 	}
 
 	// Wait for majority
+	numUpdated := 0
+	if isVoter {
+		numUpdated++
+	}
 	isOutdated := false
-	for {
+	for numUpdated < quorum {
 		// Get peer message
-		peerMessage := <-peerMessageChannel
+		var peerMessage *PeerMessage
+		select {
+		case peerMessage = <-peerMessageChannel:
+		case <-ctx.Done():
+			return contextError(ctx)
+		}
 		peerId := peerMessage.peerId
 		peerInfo := peerMessage.peerInfo
 		if peerInfo == PeerInfoUnreachable {
 			peerStatusTable[peerId].isReachable = false
 		} else if peerInfo == PeerInfoSuccess {
 			peerStatusTable[peerId].isUpdated = true
-		} else if peerInfo == PeerInfoFail {
-			// If any fail, must be reverted to follower
-			isOutdated = true
-			break
-		}
-
-		// Check all reachable success and majority success
-		// allReachableUpdated := true
-		numUpdated := 0
-		for i := range peerStatusTable {
-			// if peerStatusTable[i].isReachable && !peerStatusTable[i].isUpdated {
-			// 	// If reachable but not updated, continue waiting
-			// 	allReachableUpdated = false
-			// }
-			if peerStatusTable[i].isUpdated {
+			if peerIsVoter[peerId] {
+				// Learners are updated too, but do not count toward the majority
 				numUpdated++
 			}
-		}
-		// if allReachableUpdated && numUpdated >= s.m {
-		// 	break
-		// }
-		if numUpdated >= s.m {
+		} else if peerInfo == PeerInfoFail {
+			// If any fail, must have lost leadership
+			isOutdated = true
 			break
 		}
 	}
 
 	if isOutdated {
 		// If outdated, reverted to follower
-		return false
+		return ErrNotLeader
 	}
 
 	s.raftStateMutex.Lock()
 	// Update commit index
 	s.commitIndex = max(s.commitIndex, toCommitIndex)
 	// Apply to state machine
-	s.executeStateMachine(true)
+	s.executeStateMachine()
 	s.raftStateMutex.Unlock()
 
-	return true
+	return nil
 }
 
-func (s *RaftSyncinator) mustSendToFollower(peerId int64, peerMessageChannel chan<- *PeerMessage) {
-	client := NewRaftSyncinatorClient(s.rpcConns[peerId])
-
-	// Get the latest append entry input
+// Sends to the follower until it has every entry I had at the start, or
+// leadership is lost, or ctx is done
+func (s *RaftSyncinator) mustSendToFollower(ctx context.Context, peerId int64, client RaftPeerClient, peerMessageChannel chan<- *PeerMessage) {
 	s.raftStateMutex.RLock()
 	myTerm := s.term
-	appendEntryInput := s.makeAppendEntryInput(peerId)
+	targetIndex := s.lastLogIndex()
 	s.raftStateMutex.RUnlock()
 
-	// Make PRC
-	output, err := client.AppendEntries(s.getNewContext(), appendEntryInput)
-
 	hasReportedUnreachable := false
 
-	for {
+	for s.isLeaderOf(myTerm) {
+		// Make PRC with the latest entries, or the snapshot if the follower is behind it
+		rpcCtx, cancel := s.clock.WithTimeout(ctx, RPC_TIMEOUT)
+		output, err := s.sendLatestToFollower(rpcCtx, client, peerId)
+		cancel()
+		if ctx.Err() != nil {
+			// Nobody waits for the result anymore
+			return
+		}
+
 		if err != nil {
 			// If error, retry
 			if !hasReportedUnreachable {
@@ -313,78 +586,102 @@ func (s *RaftSyncinator) mustSendToFollower(peerId int64, peerMessageChannel cha
 				peerMessageChannel <- &PeerMessage{peerId: peerId, peerInfo: PeerInfoUnreachable}
 				hasReportedUnreachable = true
 			}
-			time.Sleep(100 * time.Millisecond)
-			output, err = client.AppendEntries(s.getNewContext(), appendEntryInput)
+			retry := s.clock.NewTimer(HEARTBEAT_INTERVAL)
+			select {
+			case <-retry.C():
+			case <-ctx.Done():
+				retry.Stop()
+				return
+			}
 		} else if output.Success {
 			// If successful, update next index and match index
 			s.raftStateMutex.Lock()
-			s.nextIndex[peerId] = output.MatchedIndex + 1
-			s.matchIndex[peerId] = output.MatchedIndex
+			if !s.isMember(peerId) {
+				// Removed while I was sending
+				s.raftStateMutex.Unlock()
+				return
+			}
+			s.matchIndex[peerId] = max(s.matchIndex[peerId], output.MatchedIndex)
+			s.nextIndex[peerId] = max(s.nextIndex[peerId], s.matchIndex[peerId]+1)
+			isUpdated := s.matchIndex[peerId] >= targetIndex
 			s.raftStateMutex.Unlock()
-			// Report success
-			peerMessageChannel <- &PeerMessage{peerId: peerId, peerInfo: PeerInfoSuccess}
-			return
+			if isUpdated {
+				// Report success
+				peerMessageChannel <- &PeerMessage{peerId: peerId, peerInfo: PeerInfoSuccess}
+				return
+			}
+			// Entries are sent in batches, send the next one
 		} else if output.Term > myTerm {
 			// If I am a stale leader, revert to follower
-			s.serverStatusMutex.Lock()
-			s.serverStatus = ServerStatus_FOLLOWER
-			s.serverStatusMutex.Unlock()
-			s.raftStateMutex.Lock()
-			s.term = output.Term
-			s.raftStateMutex.Unlock()
-			// Report fail
-			peerMessageChannel <- &PeerMessage{peerId: peerId, peerInfo: PeerInfoFail}
-			return
+			s.becomeFollower(output.Term)
+			break
 		} else {
-			// If log inconsistency, decrement next index and retry
+			// If log inconsistency, skip back past the conflicting entries and retry
 			s.raftStateMutex.Lock()
-			s.nextIndex[peerId] = max(s.nextIndex[peerId]-1, 0)
-			appendEntryInput = s.makeAppendEntryInput(peerId)
+			s.nextIndex[peerId] = s.nextIndexAfterConflict(peerId, output)
 			s.raftStateMutex.Unlock()
-			output, err = client.AppendEntries(s.getNewContext(), appendEntryInput)
 		}
 	}
+
+	// Report fail
+	peerMessageChannel <- &PeerMessage{peerId: peerId, peerInfo: PeerInfoFail}
 }
 
 // Locked
-func (s *RaftSyncinator) executeStateMachine(isLeader bool) {
+func (s *RaftSyncinator) executeStateMachine() {
+	if s.lastApplied < s.commitIndex {
+		// Wake up requests waiting on the applied entries
+		defer s.notifyCommit()
+	}
+
 	// Sync state machine to commit index
 	for s.lastApplied < s.commitIndex {
 		nextToApply := s.lastApplied + 1
-		nextEntry := s.log[nextToApply]
+		nextEntry := s.logEntry(nextToApply)
+		var response *UpdateFileResponse
 		if nextEntry.FileMetaData != nil {
 			// If is not no-op, apply to state machine
-			version, err := s.metaStore.UpdateFile(s.getNewContext(), nextEntry.FileMetaData)
-			if isLeader {
-				// If is leader, cache response
-				s.pendingResponses[nextToApply] = &UpdateFileResponse{
-					version: version,
-					Err:     err,
-				}
-			}
+			response = s.applyUpdate(nextToApply, nextEntry.FileMetaData)
 		}
 		s.lastApplied = nextToApply
+		// Hand the response to the request waiting on the entry, if any
+		s.resolveCommitWaiter(nextToApply, nextEntry.Term, response)
 	}
+
+	// Compact the log once enough entries have been applied
+	s.maybeTakeSnapshot()
 }
 
 // Locked
 func (s *RaftSyncinator) makeAppendEntryInput(peerId int64) *AppendEntryInput {
 	peerNextIndex := s.nextIndex[peerId]
-	prevLogTerm := int64(0)
-	if peerNextIndex > 0 {
-		prevLogTerm = s.log[peerNextIndex-1].Term
-	}
 	appendEntryInput := &AppendEntryInput{
 		Term:         s.term,
 		LeaderId:     s.id,
-		PrevLogTerm:  prevLogTerm,
+		PrevLogTerm:  s.logTerm(peerNextIndex - 1),
 		PrevLogIndex: peerNextIndex - 1,
-		Entries:      s.log[peerNextIndex:],
+		Entries:      s.logBatchFrom(peerNextIndex),
 		LeaderCommit: s.commitIndex,
 	}
 	return appendEntryInput
 }
 
+// Locked
+func (s *RaftSyncinator) makeInstallSnapshotInput() *InstallSnapshotInput {
+	return &InstallSnapshotInput{
+		Term:     s.term,
+		LeaderId: s.id,
+		Snapshot: s.snapshot,
+	}
+}
+
+// Context for background work that no single request waits for, such as
+// replication, elections and applying committed entries
 func (s *RaftSyncinator) getNewContext() context.Context {
 	return context.Background()
 }
+
+// Converts the error of a cancelled or expired request into its gRPC status
+func contextError(ctx context.Context) error {
+	return status.FromContextError(ctx.Err()).Err()
+}
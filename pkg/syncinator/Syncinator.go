@@ -24,9 +24,11 @@ const (
 type ServerStatus int32
 
 const (
-	ServerStatus_CRASHED  ServerStatus = 0
-	ServerStatus_FOLLOWER ServerStatus = 1
-	ServerStatus_LEADER   ServerStatus = 2
+	ServerStatus_CRASHED   ServerStatus = 0
+	ServerStatus_FOLLOWER  ServerStatus = 1
+	ServerStatus_LEADER    ServerStatus = 2
+	ServerStatus_CANDIDATE ServerStatus = 3
+	ServerStatus_LEARNER   ServerStatus = 4
 )
 
 // Enum value maps for ServerStatus.
@@ -35,11 +37,15 @@ var (
 		0: "CRASHED",
 		1: "FOLLOWER",
 		2: "LEADER",
+		3: "CANDIDATE",
+		4: "LEARNER",
 	}
 	ServerStatus_value = map[string]int32{
-		"CRASHED":  0,
-		"FOLLOWER": 1,
-		"LEADER":   2,
+		"CRASHED":   0,
+		"FOLLOWER":  1,
+		"LEADER":    2,
+		"CANDIDATE": 3,
+		"LEARNER":   4,
 	}
 )
 
@@ -117,6 +123,134 @@ func (x *UnreachableFromServers) GetServerIds() []int64 {
 	return nil
 }
 
+// Faults on the messages a server sends to one peer, requests and replies alike
+type LinkFault struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerId      int64   `protobuf:"varint,1,opt,name=serverId,proto3" json:"serverId,omitempty"`
+	DropRate      float64 `protobuf:"fixed64,2,opt,name=dropRate,proto3" json:"dropRate,omitempty"`
+	DelayMillis   int64   `protobuf:"varint,3,opt,name=delayMillis,proto3" json:"delayMillis,omitempty"`
+	IsPartitioned bool    `protobuf:"varint,4,opt,name=isPartitioned,proto3" json:"isPartitioned,omitempty"`
+}
+
+func (x *LinkFault) Reset() {
+	*x = LinkFault{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkFault) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkFault) ProtoMessage() {}
+
+func (x *LinkFault) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkFault.ProtoReflect.Descriptor instead.
+func (*LinkFault) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LinkFault) GetServerId() int64 {
+	if x != nil {
+		return x.ServerId
+	}
+	return 0
+}
+
+func (x *LinkFault) GetDropRate() float64 {
+	if x != nil {
+		return x.DropRate
+	}
+	return 0
+}
+
+func (x *LinkFault) GetDelayMillis() int64 {
+	if x != nil {
+		return x.DelayMillis
+	}
+	return 0
+}
+
+func (x *LinkFault) GetIsPartitioned() bool {
+	if x != nil {
+		return x.IsPartitioned
+	}
+	return false
+}
+
+type LinkFaults struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Links []*LinkFault `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	// Applies to requests from clients, serverId is ignored
+	Clients *LinkFault `protobuf:"bytes,2,opt,name=clients,proto3" json:"clients,omitempty"`
+}
+
+func (x *LinkFaults) Reset() {
+	*x = LinkFaults{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkFaults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkFaults) ProtoMessage() {}
+
+func (x *LinkFaults) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkFaults.ProtoReflect.Descriptor instead.
+func (*LinkFaults) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LinkFaults) GetLinks() []*LinkFault {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *LinkFaults) GetClients() *LinkFault {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
 type BlockHash struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -128,7 +262,7 @@ type BlockHash struct {
 func (x *BlockHash) Reset() {
 	*x = BlockHash{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[1]
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -141,7 +275,7 @@ func (x *BlockHash) String() string {
 func (*BlockHash) ProtoMessage() {}
 
 func (x *BlockHash) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[1]
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -154,7 +288,7 @@ func (x *BlockHash) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockHash.ProtoReflect.Descriptor instead.
 func (*BlockHash) Descriptor() ([]byte, []int) {
-	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{1}
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *BlockHash) GetHash() string {
@@ -175,7 +309,7 @@ type BlockHashes struct {
 func (x *BlockHashes) Reset() {
 	*x = BlockHashes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[2]
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -188,7 +322,7 @@ func (x *BlockHashes) String() string {
 func (*BlockHashes) ProtoMessage() {}
 
 func (x *BlockHashes) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[2]
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -201,7 +335,7 @@ func (x *BlockHashes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockHashes.ProtoReflect.Descriptor instead.
 func (*BlockHashes) Descriptor() ([]byte, []int) {
-	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{2}
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *BlockHashes) GetHashes() []string {
@@ -218,12 +352,15 @@ type Block struct {
 
 	BlockData []byte `protobuf:"bytes,1,opt,name=blockData,proto3" json:"blockData,omitempty"`
 	BlockSize int32  `protobuf:"varint,2,opt,name=blockSize,proto3" json:"blockSize,omitempty"`
+	// Hash that the metadata will reference, PutBlock rejects the block if
+	// its data hashes to anything else. Not checked if empty.
+	ExpectedHash string `protobuf:"bytes,3,opt,name=expectedHash,proto3" json:"expectedHash,omitempty"`
 }
 
 func (x *Block) Reset() {
 	*x = Block{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[3]
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -236,7 +373,7 @@ func (x *Block) String() string {
 func (*Block) ProtoMessage() {}
 
 func (x *Block) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[3]
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,7 +386,7 @@ func (x *Block) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Block.ProtoReflect.Descriptor instead.
 func (*Block) Descriptor() ([]byte, []int) {
-	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{3}
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Block) GetBlockData() []byte {
@@ -266,6 +403,13 @@ func (x *Block) GetBlockSize() int32 {
 	return 0
 }
 
+func (x *Block) GetExpectedHash() string {
+	if x != nil {
+		return x.ExpectedHash
+	}
+	return ""
+}
+
 type Success struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -277,7 +421,7 @@ type Success struct {
 func (x *Success) Reset() {
 	*x = Success{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[4]
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -290,7 +434,7 @@ func (x *Success) String() string {
 func (*Success) ProtoMessage() {}
 
 func (x *Success) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[4]
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -303,7 +447,7 @@ func (x *Success) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Success.ProtoReflect.Descriptor instead.
 func (*Success) Descriptor() ([]byte, []int) {
-	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{4}
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Success) GetFlag() bool {
@@ -321,12 +465,14 @@ type FileMetaData struct {
 	Filename      string   `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
 	Version       int32    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 	BlockHashList []string `protobuf:"bytes,3,rep,name=blockHashList,proto3" json:"blockHashList,omitempty"`
+	ClientId      int64    `protobuf:"varint,4,opt,name=clientId,proto3" json:"clientId,omitempty"`
+	Sequence      int64    `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`
 }
 
 func (x *FileMetaData) Reset() {
 	*x = FileMetaData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[5]
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -339,7 +485,7 @@ func (x *FileMetaData) String() string {
 func (*FileMetaData) ProtoMessage() {}
 
 func (x *FileMetaData) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[5]
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -352,7 +498,7 @@ func (x *FileMetaData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileMetaData.ProtoReflect.Descriptor instead.
 func (*FileMetaData) Descriptor() ([]byte, []int) {
-	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{5}
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *FileMetaData) GetFilename() string {
@@ -376,6 +522,20 @@ func (x *FileMetaData) GetBlockHashList() []string {
 	return nil
 }
 
+func (x *FileMetaData) GetClientId() int64 {
+	if x != nil {
+		return x.ClientId
+	}
+	return 0
+}
+
+func (x *FileMetaData) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
 type FileInfoMap struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -387,7 +547,7 @@ type FileInfoMap struct {
 func (x *FileInfoMap) Reset() {
 	*x = FileInfoMap{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[6]
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -400,7 +560,7 @@ func (x *FileInfoMap) String() string {
 func (*FileInfoMap) ProtoMessage() {}
 
 func (x *FileInfoMap) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[6]
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -413,7 +573,7 @@ func (x *FileInfoMap) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileInfoMap.ProtoReflect.Descriptor instead.
 func (*FileInfoMap) Descriptor() ([]byte, []int) {
-	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{6}
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *FileInfoMap) GetFileInfoMap() map[string]*FileMetaData {
@@ -434,7 +594,7 @@ type Version struct {
 func (x *Version) Reset() {
 	*x = Version{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[7]
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -447,7 +607,7 @@ func (x *Version) String() string {
 func (*Version) ProtoMessage() {}
 
 func (x *Version) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[7]
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -460,7 +620,7 @@ func (x *Version) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Version.ProtoReflect.Descriptor instead.
 func (*Version) Descriptor() ([]byte, []int) {
-	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{7}
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Version) GetVersion() int32 {
@@ -481,7 +641,7 @@ type BlockStoreMap struct {
 func (x *BlockStoreMap) Reset() {
 	*x = BlockStoreMap{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[8]
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -494,7 +654,7 @@ func (x *BlockStoreMap) String() string {
 func (*BlockStoreMap) ProtoMessage() {}
 
 func (x *BlockStoreMap) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[8]
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -507,7 +667,7 @@ func (x *BlockStoreMap) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockStoreMap.ProtoReflect.Descriptor instead.
 func (*BlockStoreMap) Descriptor() ([]byte, []int) {
-	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{8}
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *BlockStoreMap) GetBlockStoreMap() map[string]*BlockHashes {
@@ -528,7 +688,7 @@ type BlockStoreAddrs struct {
 func (x *BlockStoreAddrs) Reset() {
 	*x = BlockStoreAddrs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[9]
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -541,7 +701,7 @@ func (x *BlockStoreAddrs) String() string {
 func (*BlockStoreAddrs) ProtoMessage() {}
 
 func (x *BlockStoreAddrs) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[9]
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -554,7 +714,7 @@ func (x *BlockStoreAddrs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockStoreAddrs.ProtoReflect.Descriptor instead.
 func (*BlockStoreAddrs) Descriptor() ([]byte, []int) {
-	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{9}
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *BlockStoreAddrs) GetBlockStoreAddrs() []string {
@@ -564,36 +724,32 @@ func (x *BlockStoreAddrs) GetBlockStoreAddrs() []string {
 	return nil
 }
 
-type AppendEntryInput struct {
+type LeaderHint struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Term         int64              `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
-	LeaderId     int64              `protobuf:"varint,2,opt,name=leaderId,proto3" json:"leaderId,omitempty"`
-	PrevLogIndex int64              `protobuf:"varint,3,opt,name=prevLogIndex,proto3" json:"prevLogIndex,omitempty"`
-	PrevLogTerm  int64              `protobuf:"varint,4,opt,name=prevLogTerm,proto3" json:"prevLogTerm,omitempty"`
-	Entries      []*UpdateOperation `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
-	LeaderCommit int64              `protobuf:"varint,6,opt,name=leaderCommit,proto3" json:"leaderCommit,omitempty"`
+	LeaderId   int64  `protobuf:"varint,1,opt,name=leaderId,proto3" json:"leaderId,omitempty"`
+	LeaderAddr string `protobuf:"bytes,2,opt,name=leaderAddr,proto3" json:"leaderAddr,omitempty"`
 }
 
-func (x *AppendEntryInput) Reset() {
-	*x = AppendEntryInput{}
+func (x *LeaderHint) Reset() {
+	*x = LeaderHint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[10]
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AppendEntryInput) String() string {
+func (x *LeaderHint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AppendEntryInput) ProtoMessage() {}
+func (*LeaderHint) ProtoMessage() {}
 
-func (x *AppendEntryInput) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[10]
+func (x *LeaderHint) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -604,81 +760,99 @@ func (x *AppendEntryInput) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AppendEntryInput.ProtoReflect.Descriptor instead.
-func (*AppendEntryInput) Descriptor() ([]byte, []int) {
-	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use LeaderHint.ProtoReflect.Descriptor instead.
+func (*LeaderHint) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *AppendEntryInput) GetTerm() int64 {
+func (x *LeaderHint) GetLeaderId() int64 {
 	if x != nil {
-		return x.Term
+		return x.LeaderId
 	}
 	return 0
 }
 
-func (x *AppendEntryInput) GetLeaderId() int64 {
+func (x *LeaderHint) GetLeaderAddr() string {
 	if x != nil {
-		return x.LeaderId
+		return x.LeaderAddr
 	}
-	return 0
+	return ""
 }
 
-func (x *AppendEntryInput) GetPrevLogIndex() int64 {
-	if x != nil {
-		return x.PrevLogIndex
-	}
-	return 0
+type ReplicaReadInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinIndex int64 `protobuf:"varint,1,opt,name=minIndex,proto3" json:"minIndex,omitempty"`
 }
 
-func (x *AppendEntryInput) GetPrevLogTerm() int64 {
-	if x != nil {
-		return x.PrevLogTerm
+func (x *ReplicaReadInput) Reset() {
+	*x = ReplicaReadInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *AppendEntryInput) GetEntries() []*UpdateOperation {
-	if x != nil {
-		return x.Entries
+func (x *ReplicaReadInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicaReadInput) ProtoMessage() {}
+
+func (x *ReplicaReadInput) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *AppendEntryInput) GetLeaderCommit() int64 {
+// Deprecated: Use ReplicaReadInput.ProtoReflect.Descriptor instead.
+func (*ReplicaReadInput) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReplicaReadInput) GetMinIndex() int64 {
 	if x != nil {
-		return x.LeaderCommit
+		return x.MinIndex
 	}
 	return 0
 }
 
-type AppendEntryOutput struct {
+type ReplicaFileInfoMap struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ServerId     int64 `protobuf:"varint,1,opt,name=serverId,proto3" json:"serverId,omitempty"`
-	Term         int64 `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
-	Success      bool  `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
-	MatchedIndex int64 `protobuf:"varint,4,opt,name=matchedIndex,proto3" json:"matchedIndex,omitempty"`
+	FileInfoMap     *FileInfoMap `protobuf:"bytes,1,opt,name=fileInfoMap,proto3" json:"fileInfoMap,omitempty"`
+	LastApplied     int64        `protobuf:"varint,2,opt,name=lastApplied,proto3" json:"lastApplied,omitempty"`
+	LastAppliedTerm int64        `protobuf:"varint,3,opt,name=lastAppliedTerm,proto3" json:"lastAppliedTerm,omitempty"`
 }
 
-func (x *AppendEntryOutput) Reset() {
-	*x = AppendEntryOutput{}
+func (x *ReplicaFileInfoMap) Reset() {
+	*x = ReplicaFileInfoMap{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[11]
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AppendEntryOutput) String() string {
+func (x *ReplicaFileInfoMap) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AppendEntryOutput) ProtoMessage() {}
+func (*ReplicaFileInfoMap) ProtoMessage() {}
 
-func (x *AppendEntryOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[11]
+func (x *ReplicaFileInfoMap) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -689,65 +863,1213 @@ func (x *AppendEntryOutput) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AppendEntryOutput.ProtoReflect.Descriptor instead.
-func (*AppendEntryOutput) Descriptor() ([]byte, []int) {
-	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ReplicaFileInfoMap.ProtoReflect.Descriptor instead.
+func (*ReplicaFileInfoMap) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *AppendEntryOutput) GetServerId() int64 {
+func (x *ReplicaFileInfoMap) GetFileInfoMap() *FileInfoMap {
 	if x != nil {
-		return x.ServerId
+		return x.FileInfoMap
 	}
-	return 0
+	return nil
 }
 
-func (x *AppendEntryOutput) GetTerm() int64 {
+func (x *ReplicaFileInfoMap) GetLastApplied() int64 {
 	if x != nil {
-		return x.Term
+		return x.LastApplied
 	}
 	return 0
 }
 
-func (x *AppendEntryOutput) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *AppendEntryOutput) GetMatchedIndex() int64 {
+func (x *ReplicaFileInfoMap) GetLastAppliedTerm() int64 {
 	if x != nil {
-		return x.MatchedIndex
+		return x.LastAppliedTerm
 	}
 	return 0
 }
 
-type UpdateOperation struct {
+type AppendEntryInput struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Term         int64         `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
-	FileMetaData *FileMetaData `protobuf:"bytes,2,opt,name=fileMetaData,proto3" json:"fileMetaData,omitempty"`
+	Term         int64              `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	LeaderId     int64              `protobuf:"varint,2,opt,name=leaderId,proto3" json:"leaderId,omitempty"`
+	PrevLogIndex int64              `protobuf:"varint,3,opt,name=prevLogIndex,proto3" json:"prevLogIndex,omitempty"`
+	PrevLogTerm  int64              `protobuf:"varint,4,opt,name=prevLogTerm,proto3" json:"prevLogTerm,omitempty"`
+	Entries      []*UpdateOperation `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+	LeaderCommit int64              `protobuf:"varint,6,opt,name=leaderCommit,proto3" json:"leaderCommit,omitempty"`
+}
+
+func (x *AppendEntryInput) Reset() {
+	*x = AppendEntryInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppendEntryInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendEntryInput) ProtoMessage() {}
+
+func (x *AppendEntryInput) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendEntryInput.ProtoReflect.Descriptor instead.
+func (*AppendEntryInput) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AppendEntryInput) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *AppendEntryInput) GetLeaderId() int64 {
+	if x != nil {
+		return x.LeaderId
+	}
+	return 0
+}
+
+func (x *AppendEntryInput) GetPrevLogIndex() int64 {
+	if x != nil {
+		return x.PrevLogIndex
+	}
+	return 0
+}
+
+func (x *AppendEntryInput) GetPrevLogTerm() int64 {
+	if x != nil {
+		return x.PrevLogTerm
+	}
+	return 0
+}
+
+func (x *AppendEntryInput) GetEntries() []*UpdateOperation {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *AppendEntryInput) GetLeaderCommit() int64 {
+	if x != nil {
+		return x.LeaderCommit
+	}
+	return 0
+}
+
+type AppendEntryOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerId      int64 `protobuf:"varint,1,opt,name=serverId,proto3" json:"serverId,omitempty"`
+	Term          int64 `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	Success       bool  `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	MatchedIndex  int64 `protobuf:"varint,4,opt,name=matchedIndex,proto3" json:"matchedIndex,omitempty"`
+	ConflictIndex int64 `protobuf:"varint,5,opt,name=conflictIndex,proto3" json:"conflictIndex,omitempty"`
+	ConflictTerm  int64 `protobuf:"varint,6,opt,name=conflictTerm,proto3" json:"conflictTerm,omitempty"`
+}
+
+func (x *AppendEntryOutput) Reset() {
+	*x = AppendEntryOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppendEntryOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendEntryOutput) ProtoMessage() {}
+
+func (x *AppendEntryOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendEntryOutput.ProtoReflect.Descriptor instead.
+func (*AppendEntryOutput) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AppendEntryOutput) GetServerId() int64 {
+	if x != nil {
+		return x.ServerId
+	}
+	return 0
+}
+
+func (x *AppendEntryOutput) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *AppendEntryOutput) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AppendEntryOutput) GetMatchedIndex() int64 {
+	if x != nil {
+		return x.MatchedIndex
+	}
+	return 0
+}
+
+func (x *AppendEntryOutput) GetConflictIndex() int64 {
+	if x != nil {
+		return x.ConflictIndex
+	}
+	return 0
+}
+
+func (x *AppendEntryOutput) GetConflictTerm() int64 {
+	if x != nil {
+		return x.ConflictTerm
+	}
+	return 0
+}
+
+type RequestVoteInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term               int64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	CandidateId        int64 `protobuf:"varint,2,opt,name=candidateId,proto3" json:"candidateId,omitempty"`
+	LastLogIndex       int64 `protobuf:"varint,3,opt,name=lastLogIndex,proto3" json:"lastLogIndex,omitempty"`
+	LastLogTerm        int64 `protobuf:"varint,4,opt,name=lastLogTerm,proto3" json:"lastLogTerm,omitempty"`
+	LeadershipTransfer bool  `protobuf:"varint,5,opt,name=leadershipTransfer,proto3" json:"leadershipTransfer,omitempty"`
+	PreVote            bool  `protobuf:"varint,6,opt,name=preVote,proto3" json:"preVote,omitempty"`
+}
+
+func (x *RequestVoteInput) Reset() {
+	*x = RequestVoteInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestVoteInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestVoteInput) ProtoMessage() {}
+
+func (x *RequestVoteInput) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestVoteInput.ProtoReflect.Descriptor instead.
+func (*RequestVoteInput) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RequestVoteInput) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *RequestVoteInput) GetCandidateId() int64 {
+	if x != nil {
+		return x.CandidateId
+	}
+	return 0
+}
+
+func (x *RequestVoteInput) GetLastLogIndex() int64 {
+	if x != nil {
+		return x.LastLogIndex
+	}
+	return 0
+}
+
+func (x *RequestVoteInput) GetLastLogTerm() int64 {
+	if x != nil {
+		return x.LastLogTerm
+	}
+	return 0
+}
+
+func (x *RequestVoteInput) GetLeadershipTransfer() bool {
+	if x != nil {
+		return x.LeadershipTransfer
+	}
+	return false
+}
+
+func (x *RequestVoteInput) GetPreVote() bool {
+	if x != nil {
+		return x.PreVote
+	}
+	return false
+}
+
+type RequestVoteOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerId    int64 `protobuf:"varint,1,opt,name=serverId,proto3" json:"serverId,omitempty"`
+	Term        int64 `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	VoteGranted bool  `protobuf:"varint,3,opt,name=voteGranted,proto3" json:"voteGranted,omitempty"`
+}
+
+func (x *RequestVoteOutput) Reset() {
+	*x = RequestVoteOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestVoteOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestVoteOutput) ProtoMessage() {}
+
+func (x *RequestVoteOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestVoteOutput.ProtoReflect.Descriptor instead.
+func (*RequestVoteOutput) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RequestVoteOutput) GetServerId() int64 {
+	if x != nil {
+		return x.ServerId
+	}
+	return 0
+}
+
+func (x *RequestVoteOutput) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *RequestVoteOutput) GetVoteGranted() bool {
+	if x != nil {
+		return x.VoteGranted
+	}
+	return false
+}
+
+type TransferLeadershipInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetId int64 `protobuf:"varint,1,opt,name=targetId,proto3" json:"targetId,omitempty"`
+}
+
+func (x *TransferLeadershipInput) Reset() {
+	*x = TransferLeadershipInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferLeadershipInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLeadershipInput) ProtoMessage() {}
+
+func (x *TransferLeadershipInput) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLeadershipInput.ProtoReflect.Descriptor instead.
+func (*TransferLeadershipInput) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TransferLeadershipInput) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+type TimeoutNowInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term     int64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	LeaderId int64 `protobuf:"varint,2,opt,name=leaderId,proto3" json:"leaderId,omitempty"`
+}
+
+func (x *TimeoutNowInput) Reset() {
+	*x = TimeoutNowInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeoutNowInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeoutNowInput) ProtoMessage() {}
+
+func (x *TimeoutNowInput) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeoutNowInput.ProtoReflect.Descriptor instead.
+func (*TimeoutNowInput) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TimeoutNowInput) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *TimeoutNowInput) GetLeaderId() int64 {
+	if x != nil {
+		return x.LeaderId
+	}
+	return 0
+}
+
+type RaftSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LastIncludedIndex int64              `protobuf:"varint,1,opt,name=lastIncludedIndex,proto3" json:"lastIncludedIndex,omitempty"`
+	LastIncludedTerm  int64              `protobuf:"varint,2,opt,name=lastIncludedTerm,proto3" json:"lastIncludedTerm,omitempty"`
+	MetaMap           *FileInfoMap       `protobuf:"bytes,3,opt,name=metaMap,proto3" json:"metaMap,omitempty"`
+	Configuration     *RaftConfiguration `protobuf:"bytes,4,opt,name=configuration,proto3" json:"configuration,omitempty"`
+	Sessions          []*ClientSession   `protobuf:"bytes,5,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *RaftSnapshot) Reset() {
+	*x = RaftSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftSnapshot) ProtoMessage() {}
+
+func (x *RaftSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftSnapshot.ProtoReflect.Descriptor instead.
+func (*RaftSnapshot) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RaftSnapshot) GetLastIncludedIndex() int64 {
+	if x != nil {
+		return x.LastIncludedIndex
+	}
+	return 0
+}
+
+func (x *RaftSnapshot) GetLastIncludedTerm() int64 {
+	if x != nil {
+		return x.LastIncludedTerm
+	}
+	return 0
+}
+
+func (x *RaftSnapshot) GetMetaMap() *FileInfoMap {
+	if x != nil {
+		return x.MetaMap
+	}
+	return nil
+}
+
+func (x *RaftSnapshot) GetConfiguration() *RaftConfiguration {
+	if x != nil {
+		return x.Configuration
+	}
+	return nil
+}
+
+func (x *RaftSnapshot) GetSessions() []*ClientSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type ClientSession struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId     int64 `protobuf:"varint,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
+	Sequence     int64 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Version      int32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	AppliedIndex int64 `protobuf:"varint,4,opt,name=appliedIndex,proto3" json:"appliedIndex,omitempty"`
+}
+
+func (x *ClientSession) Reset() {
+	*x = ClientSession{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientSession) ProtoMessage() {}
+
+func (x *ClientSession) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientSession.ProtoReflect.Descriptor instead.
+func (*ClientSession) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ClientSession) GetClientId() int64 {
+	if x != nil {
+		return x.ClientId
+	}
+	return 0
+}
+
+func (x *ClientSession) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ClientSession) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ClientSession) GetAppliedIndex() int64 {
+	if x != nil {
+		return x.AppliedIndex
+	}
+	return 0
+}
+
+type InstallSnapshotInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term     int64         `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	LeaderId int64         `protobuf:"varint,2,opt,name=leaderId,proto3" json:"leaderId,omitempty"`
+	Snapshot *RaftSnapshot `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *InstallSnapshotInput) Reset() {
+	*x = InstallSnapshotInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstallSnapshotInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallSnapshotInput) ProtoMessage() {}
+
+func (x *InstallSnapshotInput) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallSnapshotInput.ProtoReflect.Descriptor instead.
+func (*InstallSnapshotInput) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *InstallSnapshotInput) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *InstallSnapshotInput) GetLeaderId() int64 {
+	if x != nil {
+		return x.LeaderId
+	}
+	return 0
+}
+
+func (x *InstallSnapshotInput) GetSnapshot() *RaftSnapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type InstallSnapshotOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerId int64 `protobuf:"varint,1,opt,name=serverId,proto3" json:"serverId,omitempty"`
+	Term     int64 `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+}
+
+func (x *InstallSnapshotOutput) Reset() {
+	*x = InstallSnapshotOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstallSnapshotOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallSnapshotOutput) ProtoMessage() {}
+
+func (x *InstallSnapshotOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallSnapshotOutput.ProtoReflect.Descriptor instead.
+func (*InstallSnapshotOutput) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *InstallSnapshotOutput) GetServerId() int64 {
+	if x != nil {
+		return x.ServerId
+	}
+	return 0
+}
+
+func (x *InstallSnapshotOutput) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+type UpdateOperation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term          int64              `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	FileMetaData  *FileMetaData      `protobuf:"bytes,2,opt,name=fileMetaData,proto3" json:"fileMetaData,omitempty"`
+	Configuration *RaftConfiguration `protobuf:"bytes,3,opt,name=configuration,proto3" json:"configuration,omitempty"`
+}
+
+func (x *UpdateOperation) Reset() {
+	*x = UpdateOperation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOperation) ProtoMessage() {}
+
+func (x *UpdateOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOperation.ProtoReflect.Descriptor instead.
+func (*UpdateOperation) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpdateOperation) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *UpdateOperation) GetFileMetaData() *FileMetaData {
+	if x != nil {
+		return x.FileMetaData
+	}
+	return nil
+}
+
+func (x *UpdateOperation) GetConfiguration() *RaftConfiguration {
+	if x != nil {
+		return x.Configuration
+	}
+	return nil
+}
+
+type RaftMember struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Addr      string `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	IsLearner bool   `protobuf:"varint,3,opt,name=isLearner,proto3" json:"isLearner,omitempty"`
+}
+
+func (x *RaftMember) Reset() {
+	*x = RaftMember{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftMember) ProtoMessage() {}
+
+func (x *RaftMember) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftMember.ProtoReflect.Descriptor instead.
+func (*RaftMember) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RaftMember) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RaftMember) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *RaftMember) GetIsLearner() bool {
+	if x != nil {
+		return x.IsLearner
+	}
+	return false
+}
+
+type RaftConfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Members []*RaftMember `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (x *RaftConfiguration) Reset() {
+	*x = RaftConfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftConfiguration) ProtoMessage() {}
+
+func (x *RaftConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftConfiguration.ProtoReflect.Descriptor instead.
+func (*RaftConfiguration) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RaftConfiguration) GetMembers() []*RaftMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type RaftStableState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term     int64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	VotedFor int64 `protobuf:"varint,2,opt,name=votedFor,proto3" json:"votedFor,omitempty"`
+}
+
+func (x *RaftStableState) Reset() {
+	*x = RaftStableState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftStableState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftStableState) ProtoMessage() {}
+
+func (x *RaftStableState) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftStableState.ProtoReflect.Descriptor instead.
+func (*RaftStableState) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RaftStableState) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *RaftStableState) GetVotedFor() int64 {
+	if x != nil {
+		return x.VotedFor
+	}
+	return 0
+}
+
+type RaftLogRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartIndex int64              `protobuf:"varint,1,opt,name=startIndex,proto3" json:"startIndex,omitempty"`
+	Entries    []*UpdateOperation `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *RaftLogRecord) Reset() {
+	*x = RaftLogRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftLogRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftLogRecord) ProtoMessage() {}
+
+func (x *RaftLogRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftLogRecord.ProtoReflect.Descriptor instead.
+func (*RaftLogRecord) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RaftLogRecord) GetStartIndex() int64 {
+	if x != nil {
+		return x.StartIndex
+	}
+	return 0
+}
+
+func (x *RaftLogRecord) GetEntries() []*UpdateOperation {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type RaftStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerId      int64        `protobuf:"varint,1,opt,name=serverId,proto3" json:"serverId,omitempty"`
+	Status        ServerStatus `protobuf:"varint,2,opt,name=status,proto3,enum=syncinator.ServerStatus" json:"status,omitempty"`
+	Term          int64        `protobuf:"varint,3,opt,name=term,proto3" json:"term,omitempty"`
+	LeaderId      int64        `protobuf:"varint,4,opt,name=leaderId,proto3" json:"leaderId,omitempty"`
+	CommitIndex   int64        `protobuf:"varint,5,opt,name=commitIndex,proto3" json:"commitIndex,omitempty"`
+	LastApplied   int64        `protobuf:"varint,6,opt,name=lastApplied,proto3" json:"lastApplied,omitempty"`
+	LastLogIndex  int64        `protobuf:"varint,7,opt,name=lastLogIndex,proto3" json:"lastLogIndex,omitempty"`
+	SnapshotIndex int64        `protobuf:"varint,8,opt,name=snapshotIndex,proto3" json:"snapshotIndex,omitempty"`
+	// Set on the leader only
+	MatchIndex map[int64]int64 `protobuf:"bytes,9,rep,name=matchIndex,proto3" json:"matchIndex,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	IsPaused   bool            `protobuf:"varint,10,opt,name=isPaused,proto3" json:"isPaused,omitempty"`
+}
+
+func (x *RaftStatus) Reset() {
+	*x = RaftStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaftStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaftStatus) ProtoMessage() {}
+
+func (x *RaftStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaftStatus.ProtoReflect.Descriptor instead.
+func (*RaftStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RaftStatus) GetServerId() int64 {
+	if x != nil {
+		return x.ServerId
+	}
+	return 0
+}
+
+func (x *RaftStatus) GetStatus() ServerStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ServerStatus_CRASHED
+}
+
+func (x *RaftStatus) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *RaftStatus) GetLeaderId() int64 {
+	if x != nil {
+		return x.LeaderId
+	}
+	return 0
+}
+
+func (x *RaftStatus) GetCommitIndex() int64 {
+	if x != nil {
+		return x.CommitIndex
+	}
+	return 0
+}
+
+func (x *RaftStatus) GetLastApplied() int64 {
+	if x != nil {
+		return x.LastApplied
+	}
+	return 0
+}
+
+func (x *RaftStatus) GetLastLogIndex() int64 {
+	if x != nil {
+		return x.LastLogIndex
+	}
+	return 0
+}
+
+func (x *RaftStatus) GetSnapshotIndex() int64 {
+	if x != nil {
+		return x.SnapshotIndex
+	}
+	return 0
+}
+
+func (x *RaftStatus) GetMatchIndex() map[int64]int64 {
+	if x != nil {
+		return x.MatchIndex
+	}
+	return nil
+}
+
+func (x *RaftStatus) GetIsPaused() bool {
+	if x != nil {
+		return x.IsPaused
+	}
+	return false
+}
+
+// Log indexes in [startIndex, endIndex)
+type LogRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartIndex int64 `protobuf:"varint,1,opt,name=startIndex,proto3" json:"startIndex,omitempty"`
+	EndIndex   int64 `protobuf:"varint,2,opt,name=endIndex,proto3" json:"endIndex,omitempty"`
+}
+
+func (x *LogRange) Reset() {
+	*x = LogRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogRange) ProtoMessage() {}
+
+func (x *LogRange) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogRange.ProtoReflect.Descriptor instead.
+func (*LogRange) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *LogRange) GetStartIndex() int64 {
+	if x != nil {
+		return x.StartIndex
+	}
+	return 0
+}
+
+func (x *LogRange) GetEndIndex() int64 {
+	if x != nil {
+		return x.EndIndex
+	}
+	return 0
+}
+
+type LogEntries struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartIndex    int64              `protobuf:"varint,1,opt,name=startIndex,proto3" json:"startIndex,omitempty"`
+	Entries       []*UpdateOperation `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	SnapshotIndex int64              `protobuf:"varint,3,opt,name=snapshotIndex,proto3" json:"snapshotIndex,omitempty"`
+	LastLogIndex  int64              `protobuf:"varint,4,opt,name=lastLogIndex,proto3" json:"lastLogIndex,omitempty"`
 }
 
-func (x *UpdateOperation) Reset() {
-	*x = UpdateOperation{}
+func (x *LogEntries) Reset() {
+	*x = LogEntries{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[12]
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateOperation) String() string {
+func (x *LogEntries) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOperation) ProtoMessage() {}
+func (*LogEntries) ProtoMessage() {}
 
-func (x *UpdateOperation) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[12]
+func (x *LogEntries) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -758,25 +2080,39 @@ func (x *UpdateOperation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOperation.ProtoReflect.Descriptor instead.
-func (*UpdateOperation) Descriptor() ([]byte, []int) {
-	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use LogEntries.ProtoReflect.Descriptor instead.
+func (*LogEntries) Descriptor() ([]byte, []int) {
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *UpdateOperation) GetTerm() int64 {
+func (x *LogEntries) GetStartIndex() int64 {
 	if x != nil {
-		return x.Term
+		return x.StartIndex
 	}
 	return 0
 }
 
-func (x *UpdateOperation) GetFileMetaData() *FileMetaData {
+func (x *LogEntries) GetEntries() []*UpdateOperation {
 	if x != nil {
-		return x.FileMetaData
+		return x.Entries
 	}
 	return nil
 }
 
+func (x *LogEntries) GetSnapshotIndex() int64 {
+	if x != nil {
+		return x.SnapshotIndex
+	}
+	return 0
+}
+
+func (x *LogEntries) GetLastLogIndex() int64 {
+	if x != nil {
+		return x.LastLogIndex
+	}
+	return 0
+}
+
 type RaftInternalState struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -792,7 +2128,7 @@ type RaftInternalState struct {
 func (x *RaftInternalState) Reset() {
 	*x = RaftInternalState{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[13]
+		mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -805,7 +2141,7 @@ func (x *RaftInternalState) String() string {
 func (*RaftInternalState) ProtoMessage() {}
 
 func (x *RaftInternalState) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[13]
+	mi := &file_pkg_syncinator_SyncStore_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -818,7 +2154,7 @@ func (x *RaftInternalState) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RaftInternalState.ProtoReflect.Descriptor instead.
 func (*RaftInternalState) Descriptor() ([]byte, []int) {
-	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{13}
+	return file_pkg_syncinator_SyncStore_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *RaftInternalState) GetStatus() ServerStatus {
@@ -859,192 +2195,438 @@ func (x *RaftInternalState) GetMetaMap() *FileInfoMap {
 var File_pkg_syncinator_SyncStore_proto protoreflect.FileDescriptor
 
 var file_pkg_syncinator_SyncStore_proto_rawDesc = []byte{
-	0x0a, 0x1d, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f,
-	0x53, 0x75, 0x72, 0x66, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x09, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74,
-	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x36, 0x0a, 0x16, 0x55, 0x6e, 0x72, 0x65, 0x61,
-	0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x03, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22,
-	0x1f, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04,
-	0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68,
-	0x22, 0x25, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12,
-	0x16, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x43, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1c,
-	0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x1d, 0x0a, 0x07,
+	0x0a, 0x1e, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72,
+	0x2f, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0a, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x1a, 0x1b, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d,
+	0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x36, 0x0a, 0x16, 0x55, 0x6e, 0x72,
+	0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64,
+	0x73, 0x22, 0x8b, 0x01, 0x0a, 0x09, 0x4c, 0x69, 0x6e, 0x6b, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x72, 0x6f, 0x70, 0x52, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x64,
+	0x72, 0x6f, 0x70, 0x52, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x6c, 0x61, 0x79,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x64, 0x65,
+	0x6c, 0x61, 0x79, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x69, 0x73, 0x50,
+	0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0d, 0x69, 0x73, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x22,
+	0x6a, 0x0a, 0x0a, 0x4c, 0x69, 0x6e, 0x6b, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x2b, 0x0a,
+	0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73,
+	0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x46, 0x61,
+	0x75, 0x6c, 0x74, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x79,
+	0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x46, 0x61, 0x75,
+	0x6c, 0x74, 0x52, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x1f, 0x0a, 0x09, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x25, 0x0a, 0x0b,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x68,
+	0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x68, 0x61, 0x73,
+	0x68, 0x65, 0x73, 0x22, 0x67, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1c, 0x0a, 0x09,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x22, 0x1d, 0x0a, 0x07,
 	0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6c, 0x61, 0x67, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x6c, 0x61, 0x67, 0x22, 0x6a, 0x0a, 0x0c, 0x46,
-	0x69, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x66,
-	0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66,
-	0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x4c, 0x69,
-	0x73, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48,
-	0x61, 0x73, 0x68, 0x4c, 0x69, 0x73, 0x74, 0x22, 0xb1, 0x01, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x65,
-	0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x12, 0x49, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x49,
-	0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x73,
-	0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66,
-	0x6f, 0x4d, 0x61, 0x70, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d,
-	0x61, 0x70, 0x1a, 0x57, 0x0a, 0x10, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61,
-	0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2d, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74,
-	0x6f, 0x72, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x23, 0x0a, 0x07, 0x56,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x22, 0xbc, 0x01, 0x0a, 0x0d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d,
-	0x61, 0x70, 0x12, 0x51, 0x0a, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65,
-	0x4d, 0x61, 0x70, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x73, 0x75, 0x72, 0x66,
-	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65,
-	0x4d, 0x61, 0x70, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x61,
-	0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f,
-	0x72, 0x65, 0x4d, 0x61, 0x70, 0x1a, 0x58, 0x0a, 0x12, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74,
-	0x6f, 0x72, 0x65, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73,
-	0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61,
-	0x73, 0x68, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0x3b, 0x0a, 0x0f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x64,
-	0x72, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65,
-	0x41, 0x64, 0x64, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x62, 0x6c, 0x6f,
-	0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x64, 0x72, 0x73, 0x22, 0xe2, 0x01, 0x0a,
-	0x10, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x49, 0x6e, 0x70, 0x75,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x6c, 0x61, 0x67, 0x22, 0xa2, 0x01, 0x0a, 0x0c,
+	0x46, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x4c,
+	0x69, 0x73, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x48, 0x61, 0x73, 0x68, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x22, 0xb3, 0x01, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70,
+	0x12, 0x4a, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x2e, 0x46,
+	0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0b, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x1a, 0x58, 0x0a, 0x10,
+	0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46,
+	0x69, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x23, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xbe, 0x01, 0x0a, 0x0d,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x61, 0x70, 0x12, 0x52, 0x0a,
+	0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x61, 0x70, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x61, 0x70, 0x2e,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x61,
+	0x70, 0x1a, 0x59, 0x0a, 0x12, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d,
+	0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2d, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69,
+	0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x65,
+	0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3b, 0x0a, 0x0f,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x64, 0x72, 0x73, 0x12,
+	0x28, 0x0a, 0x0f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x64,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x64, 0x72, 0x73, 0x22, 0x48, 0x0a, 0x0a, 0x4c, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x48, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x41, 0x64, 0x64,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x41,
+	0x64, 0x64, 0x72, 0x22, 0x2e, 0x0a, 0x10, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x52, 0x65,
+	0x61, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x69, 0x6e, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x22, 0x9b, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x46,
+	0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x12, 0x39, 0x0a, 0x0b, 0x66, 0x69,
+	0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x52, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x4d, 0x61, 0x70, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x70, 0x70,
+	0x6c, 0x69, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74,
+	0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x41,
+	0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x54, 0x65, 0x72, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x54, 0x65, 0x72,
+	0x6d, 0x22, 0xe3, 0x01, 0x0a, 0x10, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x4c, 0x6f,
+	0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x72,
+	0x65, 0x76, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72,
+	0x65, 0x76, 0x4c, 0x6f, 0x67, 0x54, 0x65, 0x72, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x70, 0x72, 0x65, 0x76, 0x4c, 0x6f, 0x67, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x35, 0x0a, 0x07,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22, 0xcb, 0x01, 0x0a, 0x11, 0x41, 0x70, 0x70, 0x65,
+	0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x24, 0x0a, 0x0d, 0x63,
+	0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x54, 0x65, 0x72,
+	0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63,
+	0x74, 0x54, 0x65, 0x72, 0x6d, 0x22, 0xd8, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x56, 0x6f, 0x74, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65,
+	0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x20,
+	0x0a, 0x0b, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x49, 0x64,
+	0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x54,
+	0x65, 0x72, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x4c,
+	0x6f, 0x67, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x2e, 0x0a, 0x12, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x68, 0x69, 0x70, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x12, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x56, 0x6f, 0x74,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x65, 0x56, 0x6f, 0x74, 0x65,
+	0x22, 0x65, 0x0a, 0x11, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x76, 0x6f, 0x74, 0x65, 0x47, 0x72, 0x61,
+	0x6e, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x76, 0x6f, 0x74, 0x65,
+	0x47, 0x72, 0x61, 0x6e, 0x74, 0x65, 0x64, 0x22, 0x35, 0x0a, 0x17, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x22, 0x41,
+	0x0a, 0x0f, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4e, 0x6f, 0x77, 0x49, 0x6e, 0x70, 0x75,
 	0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
 	0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49,
 	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49,
-	0x64, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x4c, 0x6f, 0x67,
-	0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x76, 0x4c, 0x6f, 0x67,
-	0x54, 0x65, 0x72, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x76,
-	0x4c, 0x6f, 0x67, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x34, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69,
-	0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73,
-	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a,
-	0x0c, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0c, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x22, 0x81, 0x01, 0x0a, 0x11, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
-	0x73, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64,
-	0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x62, 0x0a, 0x0f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4f,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x3b, 0x0a, 0x0c,
-	0x66, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46,
-	0x69, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0c, 0x66, 0x69, 0x6c,
-	0x65, 0x4d, 0x65, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x22, 0xda, 0x01, 0x0a, 0x11, 0x52, 0x61,
-	0x66, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
-	0x2f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x17, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
-	0x74, 0x65, 0x72, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x6e,
-	0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2c, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x03, 0x6c, 0x6f, 0x67, 0x12, 0x30, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x61, 0x4d, 0x61, 0x70, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72,
-	0x65, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x52, 0x07, 0x6d,
-	0x65, 0x74, 0x61, 0x4d, 0x61, 0x70, 0x2a, 0x35, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x52, 0x41, 0x53, 0x48, 0x45,
-	0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x4f, 0x4c, 0x4c, 0x4f, 0x57, 0x45, 0x52, 0x10,
-	0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x45, 0x41, 0x44, 0x45, 0x52, 0x10, 0x02, 0x32, 0xfd, 0x01,
-	0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x34, 0x0a, 0x08,
-	0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x14, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73,
-	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x10,
-	0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x22, 0x00, 0x12, 0x32, 0x0a, 0x08, 0x50, 0x75, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x10,
-	0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x1a, 0x12, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x75, 0x63,
-	0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0d, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e,
-	0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x16, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74,
-	0x6f, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x1a,
-	0x16, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0e, 0x47, 0x65, 0x74,
-	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
-	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x00, 0x32, 0xa0, 0x02,
-	0x0a, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x42, 0x0a, 0x0e, 0x47,
+	0x64, 0x22, 0x97, 0x02, 0x0a, 0x0c, 0x52, 0x61, 0x66, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x12, 0x2c, 0x0a, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6c,
+	0x61, 0x73, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x2a, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64,
+	0x54, 0x65, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x6c, 0x61, 0x73, 0x74,
+	0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x31, 0x0a, 0x07,
+	0x6d, 0x65, 0x74, 0x61, 0x4d, 0x61, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x61, 0x4d, 0x61, 0x70, 0x12,
+	0x43, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x52, 0x61, 0x66, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x85, 0x01, 0x0a, 0x0d,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x22, 0x0a, 0x0c, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x22, 0x7c, 0x0a, 0x14, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12,
+	0x1a, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x08, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x52, 0x61, 0x66, 0x74, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x22, 0x47, 0x0a, 0x15, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x22, 0xa8, 0x01, 0x0a, 0x0f, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x65,
+	0x72, 0x6d, 0x12, 0x3c, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x44, 0x61,
+	0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69,
+	0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x43, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x52, 0x61, 0x66, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x4e, 0x0a, 0x0a, 0x52, 0x61, 0x66, 0x74, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x73, 0x4c, 0x65, 0x61,
+	0x72, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x4c, 0x65,
+	0x61, 0x72, 0x6e, 0x65, 0x72, 0x22, 0x45, 0x0a, 0x11, 0x52, 0x61, 0x66, 0x74, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x07, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x79,
+	0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x52, 0x61, 0x66, 0x74, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x41, 0x0a, 0x0f,
+	0x52, 0x61, 0x66, 0x74, 0x53, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74,
+	0x65, 0x72, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x6f, 0x74, 0x65, 0x64, 0x46, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x76, 0x6f, 0x74, 0x65, 0x64, 0x46, 0x6f, 0x72, 0x22,
+	0x66, 0x0a, 0x0d, 0x52, 0x61, 0x66, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x35, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0xbb, 0x03, 0x0a, 0x0a, 0x52, 0x61, 0x66, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x30, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x18, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x70,
+	0x70, 0x6c, 0x69, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73,
+	0x74, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74,
+	0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
+	0x6c, 0x61, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x24, 0x0a, 0x0d,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x46, 0x0a, 0x0a, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x52, 0x61, 0x66, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x73,
+	0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73,
+	0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x1a, 0x3d, 0x0a, 0x0f, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x46, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0xad, 0x01,
+	0x0a, 0x0a, 0x4c, 0x6f, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x35, 0x0a, 0x07,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x73,
+	0x74, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0xdd, 0x01,
+	0x0a, 0x11, 0x52, 0x61, 0x66, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2d, 0x0a, 0x03, 0x6c,
+	0x6f, 0x67, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69,
+	0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x31, 0x0a, 0x07, 0x6d, 0x65,
+	0x74, 0x61, 0x4d, 0x61, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x79,
+	0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x4d, 0x61, 0x70, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x61, 0x4d, 0x61, 0x70, 0x2a, 0x51, 0x0a,
+	0x0c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a,
+	0x07, 0x43, 0x52, 0x41, 0x53, 0x48, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x4f,
+	0x4c, 0x4c, 0x4f, 0x57, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x45, 0x41, 0x44,
+	0x45, 0x52, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x41, 0x4e, 0x44, 0x49, 0x44, 0x41, 0x54,
+	0x45, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x45, 0x41, 0x52, 0x4e, 0x45, 0x52, 0x10, 0x04,
+	0x32, 0xfa, 0x02, 0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12,
+	0x36, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x15, 0x2e, 0x73, 0x79,
+	0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61,
+	0x73, 0x68, 0x1a, 0x11, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x08, 0x50, 0x75, 0x74, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x11, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x43, 0x0a,
+	0x0d, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x17,
+	0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x1a, 0x17, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73,
+	0x22, 0x00, 0x12, 0x43, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61,
+	0x73, 0x68, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x73,
+	0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48,
+	0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x73, 0x12, 0x15, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x11, 0x2e, 0x73, 0x79,
+	0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x00,
+	0x28, 0x01, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x09, 0x50, 0x75, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x73, 0x12, 0x11, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x28, 0x01, 0x32, 0xa6, 0x02,
+	0x0a, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x43, 0x0a, 0x0e, 0x47,
 	0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x12, 0x16, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72,
-	0x65, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x22, 0x00, 0x12,
-	0x3b, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x17, 0x2e,
-	0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x65,
-	0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x12, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f,
-	0x72, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x10,
-	0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x61, 0x70,
-	0x12, 0x16, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x1a, 0x18, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73,
-	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d,
-	0x61, 0x70, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x53, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x64, 0x72, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x64, 0x72, 0x73, 0x22, 0x00,
-	0x32, 0xfe, 0x05, 0x0a, 0x0d, 0x52, 0x61, 0x66, 0x74, 0x53, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f,
-	0x72, 0x65, 0x12, 0x4c, 0x0a, 0x0d, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72,
-	0x69, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
-	0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x49, 0x6e, 0x70, 0x75, 0x74,
-	0x1a, 0x1c, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x41, 0x70, 0x70,
-	0x65, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x00,
-	0x12, 0x39, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72,
-	0x65, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0d, 0x53,
-	0x65, 0x6e, 0x64, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x16, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0e, 0x47, 0x65,
-	0x74, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x12, 0x16, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x22, 0x00, 0x12, 0x3b,
-	0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x17, 0x2e, 0x73,
-	0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x74,
-	0x61, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x12, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72,
-	0x65, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x10, 0x47,
-	0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x61, 0x70, 0x12,
-	0x16, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x1a, 0x18, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74,
-	0x6f, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x61,
-	0x70, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53,
-	0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x64, 0x72, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x22, 0x00,
+	0x12, 0x3d, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x18,
+	0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x6c, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69,
+	0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12,
+	0x48, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x4d, 0x61, 0x70, 0x12, 0x17, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x1a, 0x19, 0x2e, 0x73,
+	0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x4d, 0x61, 0x70, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x12, 0x47, 0x65, 0x74,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x64, 0x72, 0x73, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x41,
+	0x64, 0x64, 0x72, 0x73, 0x22, 0x00, 0x32, 0x94, 0x0d, 0x0a, 0x0e, 0x52, 0x61, 0x66, 0x74, 0x53,
+	0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x4e, 0x0a, 0x0d, 0x41, 0x70, 0x70,
+	0x65, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x73, 0x79, 0x6e,
+	0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x1a, 0x1d, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69,
+	0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69,
+	0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x56, 0x6f, 0x74,
+	0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x1a, 0x1d, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0f, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6c, 0x6c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x20, 0x2e, 0x73, 0x79, 0x6e,
+	0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x1a, 0x21, 0x2e, 0x73,
+	0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22,
+	0x00, 0x12, 0x3a, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x3e, 0x0a,
+	0x0d, 0x53, 0x65, 0x6e, 0x64, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x50, 0x0a,
+	0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x68, 0x69, 0x70, 0x12, 0x23, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x68, 0x69, 0x70, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69,
+	0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12,
+	0x40, 0x0a, 0x0a, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4e, 0x6f, 0x77, 0x12, 0x1b, 0x2e,
+	0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x4e, 0x6f, 0x77, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e,
+	0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22,
+	0x00, 0x12, 0x3a, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x16,
+	0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x52, 0x61, 0x66, 0x74,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x3d, 0x0a,
+	0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x16, 0x2e,
+	0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x52, 0x61, 0x66, 0x74, 0x4d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0e,
+	0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x4c, 0x65, 0x61, 0x72, 0x6e, 0x65, 0x72, 0x12, 0x16,
+	0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x52, 0x61, 0x66, 0x74,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x43, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70,
+	0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x18, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69,
+	0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e,
+	0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+	0x00, 0x12, 0x48, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x4d, 0x61, 0x70, 0x12, 0x17, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x1a, 0x19,
+	0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x61, 0x70, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x12, 0x47,
+	0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x64, 0x72,
+	0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b, 0x2e, 0x73, 0x79, 0x6e, 0x63,
+	0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x41, 0x64, 0x64, 0x72, 0x73, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61,
+	0x70, 0x12, 0x1c, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x52, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x1a,
+	0x1e, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x61, 0x70, 0x22,
+	0x00, 0x12, 0x41, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x52, 0x61, 0x66, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x73, 0x79, 0x6e,
+	0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x52, 0x61, 0x66, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x45, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x14, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x16, 0x2e, 0x73, 0x79,
+	0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x45, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x1a, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x6c,
-	0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x64, 0x64, 0x72, 0x73, 0x22, 0x00, 0x12,
-	0x4a, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x74,
-	0x61, 0x74, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1c, 0x2e, 0x73, 0x75,
-	0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x52, 0x61, 0x66, 0x74, 0x49, 0x6e, 0x74, 0x65,
-	0x72, 0x6e, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x07, 0x52,
-	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12,
-	0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x05, 0x43, 0x72, 0x61, 0x73, 0x68, 0x12, 0x16, 0x2e,
+	0x79, 0x1a, 0x1d, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x52,
+	0x61, 0x66, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x22, 0x00, 0x12, 0x38, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72,
-	0x65, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x19, 0x4d,
-	0x61, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x55, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68,
-	0x61, 0x62, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x21, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x73,
-	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65,
-	0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x1a, 0x12, 0x2e, 0x73, 0x75,
-	0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22,
-	0x00, 0x42, 0x1c, 0x5a, 0x1a, 0x63, 0x73, 0x65, 0x32, 0x32, 0x34, 0x2f, 0x70, 0x72, 0x6f, 0x6a,
-	0x35, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x05,
+	0x43, 0x72, 0x61, 0x73, 0x68, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e,
+	0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x22, 0x00, 0x12, 0x56, 0x0a, 0x19, 0x4d, 0x61, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x55, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x72, 0x6f,
+	0x6d, 0x12, 0x22, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x55,
+	0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x73, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0d,
+	0x53, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x16, 0x2e,
+	0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x46,
+	0x61, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x05,
+	0x50, 0x61, 0x75, 0x73, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e,
+	0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x42, 0x1d, 0x5a,
+	0x1b, 0x63, 0x73, 0x65, 0x32, 0x32, 0x34, 0x2f, 0x70, 0x72, 0x6f, 0x6a, 0x35, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1060,80 +2642,144 @@ func file_pkg_syncinator_SyncStore_proto_rawDescGZIP() []byte {
 }
 
 var file_pkg_syncinator_SyncStore_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pkg_syncinator_SyncStore_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_pkg_syncinator_SyncStore_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_pkg_syncinator_SyncStore_proto_goTypes = []interface{}{
-	(ServerStatus)(0),              // 0: syncinator.ServerStatus
-	(*UnreachableFromServers)(nil), // 1: syncinator.UnreachableFromServers
-	(*BlockHash)(nil),              // 2: syncinator.BlockHash
-	(*BlockHashes)(nil),            // 3: syncinator.BlockHashes
-	(*Block)(nil),                  // 4: syncinator.Block
-	(*Success)(nil),                // 5: syncinator.Success
-	(*FileMetaData)(nil),           // 6: syncinator.FileMetaData
-	(*FileInfoMap)(nil),            // 7: syncinator.FileInfoMap
-	(*Version)(nil),                // 8: syncinator.Version
-	(*BlockStoreMap)(nil),          // 9: syncinator.BlockStoreMap
-	(*BlockStoreAddrs)(nil),        // 10: syncinator.BlockStoreAddrs
-	(*AppendEntryInput)(nil),       // 11: syncinator.AppendEntryInput
-	(*AppendEntryOutput)(nil),      // 12: syncinator.AppendEntryOutput
-	(*UpdateOperation)(nil),        // 13: syncinator.UpdateOperation
-	(*RaftInternalState)(nil),      // 14: syncinator.RaftInternalState
-	nil,                            // 15: syncinator.FileInfoMap.FileInfoMapEntry
-	nil,                            // 16: syncinator.BlockStoreMap.BlockStoreMapEntry
-	(*emptypb.Empty)(nil),          // 17: google.protobuf.Empty
+	(ServerStatus)(0),               // 0: syncinator.ServerStatus
+	(*UnreachableFromServers)(nil),  // 1: syncinator.UnreachableFromServers
+	(*LinkFault)(nil),               // 2: syncinator.LinkFault
+	(*LinkFaults)(nil),              // 3: syncinator.LinkFaults
+	(*BlockHash)(nil),               // 4: syncinator.BlockHash
+	(*BlockHashes)(nil),             // 5: syncinator.BlockHashes
+	(*Block)(nil),                   // 6: syncinator.Block
+	(*Success)(nil),                 // 7: syncinator.Success
+	(*FileMetaData)(nil),            // 8: syncinator.FileMetaData
+	(*FileInfoMap)(nil),             // 9: syncinator.FileInfoMap
+	(*Version)(nil),                 // 10: syncinator.Version
+	(*BlockStoreMap)(nil),           // 11: syncinator.BlockStoreMap
+	(*BlockStoreAddrs)(nil),         // 12: syncinator.BlockStoreAddrs
+	(*LeaderHint)(nil),              // 13: syncinator.LeaderHint
+	(*ReplicaReadInput)(nil),        // 14: syncinator.ReplicaReadInput
+	(*ReplicaFileInfoMap)(nil),      // 15: syncinator.ReplicaFileInfoMap
+	(*AppendEntryInput)(nil),        // 16: syncinator.AppendEntryInput
+	(*AppendEntryOutput)(nil),       // 17: syncinator.AppendEntryOutput
+	(*RequestVoteInput)(nil),        // 18: syncinator.RequestVoteInput
+	(*RequestVoteOutput)(nil),       // 19: syncinator.RequestVoteOutput
+	(*TransferLeadershipInput)(nil), // 20: syncinator.TransferLeadershipInput
+	(*TimeoutNowInput)(nil),         // 21: syncinator.TimeoutNowInput
+	(*RaftSnapshot)(nil),            // 22: syncinator.RaftSnapshot
+	(*ClientSession)(nil),           // 23: syncinator.ClientSession
+	(*InstallSnapshotInput)(nil),    // 24: syncinator.InstallSnapshotInput
+	(*InstallSnapshotOutput)(nil),   // 25: syncinator.InstallSnapshotOutput
+	(*UpdateOperation)(nil),         // 26: syncinator.UpdateOperation
+	(*RaftMember)(nil),              // 27: syncinator.RaftMember
+	(*RaftConfiguration)(nil),       // 28: syncinator.RaftConfiguration
+	(*RaftStableState)(nil),         // 29: syncinator.RaftStableState
+	(*RaftLogRecord)(nil),           // 30: syncinator.RaftLogRecord
+	(*RaftStatus)(nil),              // 31: syncinator.RaftStatus
+	(*LogRange)(nil),                // 32: syncinator.LogRange
+	(*LogEntries)(nil),              // 33: syncinator.LogEntries
+	(*RaftInternalState)(nil),       // 34: syncinator.RaftInternalState
+	nil,                             // 35: syncinator.FileInfoMap.FileInfoMapEntry
+	nil,                             // 36: syncinator.BlockStoreMap.BlockStoreMapEntry
+	nil,                             // 37: syncinator.RaftStatus.MatchIndexEntry
+	(*emptypb.Empty)(nil),           // 38: google.protobuf.Empty
 }
 var file_pkg_syncinator_SyncStore_proto_depIdxs = []int32{
-	15, // 0: syncinator.FileInfoMap.fileInfoMap:type_name -> syncinator.FileInfoMap.FileInfoMapEntry
-	16, // 1: syncinator.BlockStoreMap.blockStoreMap:type_name -> syncinator.BlockStoreMap.BlockStoreMapEntry
-	13, // 2: syncinator.AppendEntryInput.entries:type_name -> syncinator.UpdateOperation
-	6,  // 3: syncinator.UpdateOperation.fileMetaData:type_name -> syncinator.FileMetaData
-	0,  // 4: syncinator.RaftInternalState.status:type_name -> syncinator.ServerStatus
-	13, // 5: syncinator.RaftInternalState.log:type_name -> syncinator.UpdateOperation
-	7,  // 6: syncinator.RaftInternalState.metaMap:type_name -> syncinator.FileInfoMap
-	6,  // 7: syncinator.FileInfoMap.FileInfoMapEntry.value:type_name -> syncinator.FileMetaData
-	3,  // 8: syncinator.BlockStoreMap.BlockStoreMapEntry.value:type_name -> syncinator.BlockHashes
-	2,  // 9: syncinator.BlockStore.GetBlock:input_type -> syncinator.BlockHash
-	4,  // 10: syncinator.BlockStore.PutBlock:input_type -> syncinator.Block
-	3,  // 11: syncinator.BlockStore.MissingBlocks:input_type -> syncinator.BlockHashes
-	17, // 12: syncinator.BlockStore.GetBlockHashes:input_type -> google.protobuf.Empty
-	17, // 13: syncinator.MetaStore.GetFileInfoMap:input_type -> google.protobuf.Empty
-	6,  // 14: syncinator.MetaStore.UpdateFile:input_type -> syncinator.FileMetaData
-	3,  // 15: syncinator.MetaStore.GetBlockStoreMap:input_type -> syncinator.BlockHashes
-	17, // 16: syncinator.MetaStore.GetBlockStoreAddrs:input_type -> google.protobuf.Empty
-	11, // 17: syncinator.RaftSyncinator.AppendEntries:input_type -> syncinator.AppendEntryInput
-	17, // 18: syncinator.RaftSyncinator.SetLeader:input_type -> google.protobuf.Empty
-	17, // 19: syncinator.RaftSyncinator.SendHeartbeat:input_type -> google.protobuf.Empty
-	17, // 20: syncinator.RaftSyncinator.GetFileInfoMap:input_type -> google.protobuf.Empty
-	6,  // 21: syncinator.RaftSyncinator.UpdateFile:input_type -> syncinator.FileMetaData
-	3,  // 22: syncinator.RaftSyncinator.GetBlockStoreMap:input_type -> syncinator.BlockHashes
-	17, // 23: syncinator.RaftSyncinator.GetBlockStoreAddrs:input_type -> google.protobuf.Empty
-	17, // 24: syncinator.RaftSyncinator.GetInternalState:input_type -> google.protobuf.Empty
-	17, // 25: syncinator.RaftSyncinator.Restore:input_type -> google.protobuf.Empty
-	17, // 26: syncinator.RaftSyncinator.Crash:input_type -> google.protobuf.Empty
-	1,  // 27: syncinator.RaftSyncinator.MakeServerUnreachableFrom:input_type -> syncinator.UnreachableFromServers
-	4,  // 28: syncinator.BlockStore.GetBlock:output_type -> syncinator.Block
-	5,  // 29: syncinator.BlockStore.PutBlock:output_type -> syncinator.Success
-	3,  // 30: syncinator.BlockStore.MissingBlocks:output_type -> syncinator.BlockHashes
-	3,  // 31: syncinator.BlockStore.GetBlockHashes:output_type -> syncinator.BlockHashes
-	7,  // 32: syncinator.MetaStore.GetFileInfoMap:output_type -> syncinator.FileInfoMap
-	8,  // 33: syncinator.MetaStore.UpdateFile:output_type -> syncinator.Version
-	9,  // 34: syncinator.MetaStore.GetBlockStoreMap:output_type -> syncinator.BlockStoreMap
-	10, // 35: syncinator.MetaStore.GetBlockStoreAddrs:output_type -> syncinator.BlockStoreAddrs
-	12, // 36: syncinator.RaftSyncinator.AppendEntries:output_type -> syncinator.AppendEntryOutput
-	5,  // 37: syncinator.RaftSyncinator.SetLeader:output_type -> syncinator.Success
-	5,  // 38: syncinator.RaftSyncinator.SendHeartbeat:output_type -> syncinator.Success
-	7,  // 39: syncinator.RaftSyncinator.GetFileInfoMap:output_type -> syncinator.FileInfoMap
-	8,  // 40: syncinator.RaftSyncinator.UpdateFile:output_type -> syncinator.Version
-	9,  // 41: syncinator.RaftSyncinator.GetBlockStoreMap:output_type -> syncinator.BlockStoreMap
-	10, // 42: syncinator.RaftSyncinator.GetBlockStoreAddrs:output_type -> syncinator.BlockStoreAddrs
-	14, // 43: syncinator.RaftSyncinator.GetInternalState:output_type -> syncinator.RaftInternalState
-	5,  // 44: syncinator.RaftSyncinator.Restore:output_type -> syncinator.Success
-	5,  // 45: syncinator.RaftSyncinator.Crash:output_type -> syncinator.Success
-	5,  // 46: syncinator.RaftSyncinator.MakeServerUnreachableFrom:output_type -> syncinator.Success
-	28, // [28:47] is the sub-list for method output_type
-	9,  // [9:28] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	2,  // 0: syncinator.LinkFaults.links:type_name -> syncinator.LinkFault
+	2,  // 1: syncinator.LinkFaults.clients:type_name -> syncinator.LinkFault
+	35, // 2: syncinator.FileInfoMap.fileInfoMap:type_name -> syncinator.FileInfoMap.FileInfoMapEntry
+	36, // 3: syncinator.BlockStoreMap.blockStoreMap:type_name -> syncinator.BlockStoreMap.BlockStoreMapEntry
+	9,  // 4: syncinator.ReplicaFileInfoMap.fileInfoMap:type_name -> syncinator.FileInfoMap
+	26, // 5: syncinator.AppendEntryInput.entries:type_name -> syncinator.UpdateOperation
+	9,  // 6: syncinator.RaftSnapshot.metaMap:type_name -> syncinator.FileInfoMap
+	28, // 7: syncinator.RaftSnapshot.configuration:type_name -> syncinator.RaftConfiguration
+	23, // 8: syncinator.RaftSnapshot.sessions:type_name -> syncinator.ClientSession
+	22, // 9: syncinator.InstallSnapshotInput.snapshot:type_name -> syncinator.RaftSnapshot
+	8,  // 10: syncinator.UpdateOperation.fileMetaData:type_name -> syncinator.FileMetaData
+	28, // 11: syncinator.UpdateOperation.configuration:type_name -> syncinator.RaftConfiguration
+	27, // 12: syncinator.RaftConfiguration.members:type_name -> syncinator.RaftMember
+	26, // 13: syncinator.RaftLogRecord.entries:type_name -> syncinator.UpdateOperation
+	0,  // 14: syncinator.RaftStatus.status:type_name -> syncinator.ServerStatus
+	37, // 15: syncinator.RaftStatus.matchIndex:type_name -> syncinator.RaftStatus.MatchIndexEntry
+	26, // 16: syncinator.LogEntries.entries:type_name -> syncinator.UpdateOperation
+	0,  // 17: syncinator.RaftInternalState.status:type_name -> syncinator.ServerStatus
+	26, // 18: syncinator.RaftInternalState.log:type_name -> syncinator.UpdateOperation
+	9,  // 19: syncinator.RaftInternalState.metaMap:type_name -> syncinator.FileInfoMap
+	8,  // 20: syncinator.FileInfoMap.FileInfoMapEntry.value:type_name -> syncinator.FileMetaData
+	5,  // 21: syncinator.BlockStoreMap.BlockStoreMapEntry.value:type_name -> syncinator.BlockHashes
+	4,  // 22: syncinator.BlockStore.GetBlock:input_type -> syncinator.BlockHash
+	6,  // 23: syncinator.BlockStore.PutBlock:input_type -> syncinator.Block
+	5,  // 24: syncinator.BlockStore.MissingBlocks:input_type -> syncinator.BlockHashes
+	38, // 25: syncinator.BlockStore.GetBlockHashes:input_type -> google.protobuf.Empty
+	4,  // 26: syncinator.BlockStore.GetBlocks:input_type -> syncinator.BlockHash
+	6,  // 27: syncinator.BlockStore.PutBlocks:input_type -> syncinator.Block
+	38, // 28: syncinator.MetaStore.GetFileInfoMap:input_type -> google.protobuf.Empty
+	8,  // 29: syncinator.MetaStore.UpdateFile:input_type -> syncinator.FileMetaData
+	5,  // 30: syncinator.MetaStore.GetBlockStoreMap:input_type -> syncinator.BlockHashes
+	38, // 31: syncinator.MetaStore.GetBlockStoreAddrs:input_type -> google.protobuf.Empty
+	16, // 32: syncinator.RaftSyncinator.AppendEntries:input_type -> syncinator.AppendEntryInput
+	18, // 33: syncinator.RaftSyncinator.RequestVote:input_type -> syncinator.RequestVoteInput
+	24, // 34: syncinator.RaftSyncinator.InstallSnapshot:input_type -> syncinator.InstallSnapshotInput
+	38, // 35: syncinator.RaftSyncinator.SetLeader:input_type -> google.protobuf.Empty
+	38, // 36: syncinator.RaftSyncinator.SendHeartbeat:input_type -> google.protobuf.Empty
+	20, // 37: syncinator.RaftSyncinator.TransferLeadership:input_type -> syncinator.TransferLeadershipInput
+	21, // 38: syncinator.RaftSyncinator.TimeoutNow:input_type -> syncinator.TimeoutNowInput
+	27, // 39: syncinator.RaftSyncinator.AddServer:input_type -> syncinator.RaftMember
+	27, // 40: syncinator.RaftSyncinator.RemoveServer:input_type -> syncinator.RaftMember
+	27, // 41: syncinator.RaftSyncinator.PromoteLearner:input_type -> syncinator.RaftMember
+	38, // 42: syncinator.RaftSyncinator.GetFileInfoMap:input_type -> google.protobuf.Empty
+	8,  // 43: syncinator.RaftSyncinator.UpdateFile:input_type -> syncinator.FileMetaData
+	5,  // 44: syncinator.RaftSyncinator.GetBlockStoreMap:input_type -> syncinator.BlockHashes
+	38, // 45: syncinator.RaftSyncinator.GetBlockStoreAddrs:input_type -> google.protobuf.Empty
+	14, // 46: syncinator.RaftSyncinator.GetReplicaFileInfoMap:input_type -> syncinator.ReplicaReadInput
+	38, // 47: syncinator.RaftSyncinator.GetRaftStatus:input_type -> google.protobuf.Empty
+	32, // 48: syncinator.RaftSyncinator.GetLogEntries:input_type -> syncinator.LogRange
+	38, // 49: syncinator.RaftSyncinator.GetInternalState:input_type -> google.protobuf.Empty
+	38, // 50: syncinator.RaftSyncinator.Restore:input_type -> google.protobuf.Empty
+	38, // 51: syncinator.RaftSyncinator.Crash:input_type -> google.protobuf.Empty
+	1,  // 52: syncinator.RaftSyncinator.MakeServerUnreachableFrom:input_type -> syncinator.UnreachableFromServers
+	3,  // 53: syncinator.RaftSyncinator.SetLinkFaults:input_type -> syncinator.LinkFaults
+	38, // 54: syncinator.RaftSyncinator.Pause:input_type -> google.protobuf.Empty
+	38, // 55: syncinator.RaftSyncinator.Resume:input_type -> google.protobuf.Empty
+	6,  // 56: syncinator.BlockStore.GetBlock:output_type -> syncinator.Block
+	7,  // 57: syncinator.BlockStore.PutBlock:output_type -> syncinator.Success
+	5,  // 58: syncinator.BlockStore.MissingBlocks:output_type -> syncinator.BlockHashes
+	5,  // 59: syncinator.BlockStore.GetBlockHashes:output_type -> syncinator.BlockHashes
+	6,  // 60: syncinator.BlockStore.GetBlocks:output_type -> syncinator.Block
+	7,  // 61: syncinator.BlockStore.PutBlocks:output_type -> syncinator.Success
+	9,  // 62: syncinator.MetaStore.GetFileInfoMap:output_type -> syncinator.FileInfoMap
+	10, // 63: syncinator.MetaStore.UpdateFile:output_type -> syncinator.Version
+	11, // 64: syncinator.MetaStore.GetBlockStoreMap:output_type -> syncinator.BlockStoreMap
+	12, // 65: syncinator.MetaStore.GetBlockStoreAddrs:output_type -> syncinator.BlockStoreAddrs
+	17, // 66: syncinator.RaftSyncinator.AppendEntries:output_type -> syncinator.AppendEntryOutput
+	19, // 67: syncinator.RaftSyncinator.RequestVote:output_type -> syncinator.RequestVoteOutput
+	25, // 68: syncinator.RaftSyncinator.InstallSnapshot:output_type -> syncinator.InstallSnapshotOutput
+	7,  // 69: syncinator.RaftSyncinator.SetLeader:output_type -> syncinator.Success
+	7,  // 70: syncinator.RaftSyncinator.SendHeartbeat:output_type -> syncinator.Success
+	7,  // 71: syncinator.RaftSyncinator.TransferLeadership:output_type -> syncinator.Success
+	7,  // 72: syncinator.RaftSyncinator.TimeoutNow:output_type -> syncinator.Success
+	7,  // 73: syncinator.RaftSyncinator.AddServer:output_type -> syncinator.Success
+	7,  // 74: syncinator.RaftSyncinator.RemoveServer:output_type -> syncinator.Success
+	7,  // 75: syncinator.RaftSyncinator.PromoteLearner:output_type -> syncinator.Success
+	9,  // 76: syncinator.RaftSyncinator.GetFileInfoMap:output_type -> syncinator.FileInfoMap
+	10, // 77: syncinator.RaftSyncinator.UpdateFile:output_type -> syncinator.Version
+	11, // 78: syncinator.RaftSyncinator.GetBlockStoreMap:output_type -> syncinator.BlockStoreMap
+	12, // 79: syncinator.RaftSyncinator.GetBlockStoreAddrs:output_type -> syncinator.BlockStoreAddrs
+	15, // 80: syncinator.RaftSyncinator.GetReplicaFileInfoMap:output_type -> syncinator.ReplicaFileInfoMap
+	31, // 81: syncinator.RaftSyncinator.GetRaftStatus:output_type -> syncinator.RaftStatus
+	33, // 82: syncinator.RaftSyncinator.GetLogEntries:output_type -> syncinator.LogEntries
+	34, // 83: syncinator.RaftSyncinator.GetInternalState:output_type -> syncinator.RaftInternalState
+	7,  // 84: syncinator.RaftSyncinator.Restore:output_type -> syncinator.Success
+	7,  // 85: syncinator.RaftSyncinator.Crash:output_type -> syncinator.Success
+	7,  // 86: syncinator.RaftSyncinator.MakeServerUnreachableFrom:output_type -> syncinator.Success
+	7,  // 87: syncinator.RaftSyncinator.SetLinkFaults:output_type -> syncinator.Success
+	7,  // 88: syncinator.RaftSyncinator.Pause:output_type -> syncinator.Success
+	7,  // 89: syncinator.RaftSyncinator.Resume:output_type -> syncinator.Success
+	56, // [56:90] is the sub-list for method output_type
+	22, // [22:56] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_pkg_syncinator_SyncStore_proto_init() }
@@ -1155,7 +2801,7 @@ func file_pkg_syncinator_SyncStore_proto_init() {
 			}
 		}
 		file_pkg_syncinator_SyncStore_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockHash); i {
+			switch v := v.(*LinkFault); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1167,7 +2813,7 @@ func file_pkg_syncinator_SyncStore_proto_init() {
 			}
 		}
 		file_pkg_syncinator_SyncStore_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockHashes); i {
+			switch v := v.(*LinkFaults); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1179,7 +2825,7 @@ func file_pkg_syncinator_SyncStore_proto_init() {
 			}
 		}
 		file_pkg_syncinator_SyncStore_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Block); i {
+			switch v := v.(*BlockHash); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1191,7 +2837,7 @@ func file_pkg_syncinator_SyncStore_proto_init() {
 			}
 		}
 		file_pkg_syncinator_SyncStore_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Success); i {
+			switch v := v.(*BlockHashes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1203,7 +2849,7 @@ func file_pkg_syncinator_SyncStore_proto_init() {
 			}
 		}
 		file_pkg_syncinator_SyncStore_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileMetaData); i {
+			switch v := v.(*Block); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1215,7 +2861,7 @@ func file_pkg_syncinator_SyncStore_proto_init() {
 			}
 		}
 		file_pkg_syncinator_SyncStore_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileInfoMap); i {
+			switch v := v.(*Success); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1227,7 +2873,7 @@ func file_pkg_syncinator_SyncStore_proto_init() {
 			}
 		}
 		file_pkg_syncinator_SyncStore_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Version); i {
+			switch v := v.(*FileMetaData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1239,7 +2885,7 @@ func file_pkg_syncinator_SyncStore_proto_init() {
 			}
 		}
 		file_pkg_syncinator_SyncStore_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockStoreMap); i {
+			switch v := v.(*FileInfoMap); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1251,7 +2897,7 @@ func file_pkg_syncinator_SyncStore_proto_init() {
 			}
 		}
 		file_pkg_syncinator_SyncStore_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlockStoreAddrs); i {
+			switch v := v.(*Version); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1263,7 +2909,7 @@ func file_pkg_syncinator_SyncStore_proto_init() {
 			}
 		}
 		file_pkg_syncinator_SyncStore_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AppendEntryInput); i {
+			switch v := v.(*BlockStoreMap); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1275,7 +2921,7 @@ func file_pkg_syncinator_SyncStore_proto_init() {
 			}
 		}
 		file_pkg_syncinator_SyncStore_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AppendEntryOutput); i {
+			switch v := v.(*BlockStoreAddrs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1287,7 +2933,7 @@ func file_pkg_syncinator_SyncStore_proto_init() {
 			}
 		}
 		file_pkg_syncinator_SyncStore_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateOperation); i {
+			switch v := v.(*LeaderHint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1299,6 +2945,246 @@ func file_pkg_syncinator_SyncStore_proto_init() {
 			}
 		}
 		file_pkg_syncinator_SyncStore_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicaReadInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicaFileInfoMap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppendEntryInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppendEntryOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestVoteInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestVoteOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferLeadershipInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeoutNowInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RaftSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientSession); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstallSnapshotInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstallSnapshotOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateOperation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RaftMember); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RaftConfiguration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RaftStableState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RaftLogRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RaftStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogEntries); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_syncinator_SyncStore_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RaftInternalState); i {
 			case 0:
 				return &v.state
@@ -1317,7 +3203,7 @@ func file_pkg_syncinator_SyncStore_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_syncinator_SyncStore_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   16,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   3,
 		},
@@ -1,5 +1,18 @@
 package syncinator
 
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+)
+
+var ErrNotLinearizable = fmt.Errorf("history is not linearizable")
+var ErrBlockNotFound = fmt.Errorf("block not found")
+var ErrBlockCorrupt = fmt.Errorf("block data does not match its hash")
+var ErrBlockTooLarge = fmt.Errorf("block is too large")
+var ErrBlockSizeMismatch = fmt.Errorf("block size does not match its data")
+var ErrBlockHashMismatch = fmt.Errorf("block data does not match the expected hash")
+
 const DEFAULT_META_FILENAME string = "index.db"
 
 const TOMBSTONE_HASHVALUE string = "0"
@@ -14,6 +27,20 @@ const HASH_DELIMITER string = " "
 
 const DEFAULT_BLOCK_SIZE int = 4096
 
+// Largest block a BlockStore accepts unless configured otherwise
+const DEFAULT_MAX_BLOCK_SIZE int = 1 << 20
+
+// A block stream fails once no block went through it for this long
+const BLOCK_STREAM_IDLE_TIMEOUT = time.Second
+
+// Blocks are named by the hex SHA-256 of their data, and stored on disk in
+// directories named by the first characters of it
+const BLOCK_HASH_LEN int = 2 * sha256.Size
+const BLOCK_SHARD_PREFIX_LEN int = 2
+
+// Number of independently locked maps that BlockStorage spreads blocks over
+const BLOCK_MAP_SHARDS int = 64
+
 const META_INIT_BY_FILENAME int = 0
 const META_INIT_BY_PARAMS int = 1
 const META_INIT_BY_CONFIG_STR int = 2
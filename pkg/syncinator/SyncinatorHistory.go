@@ -0,0 +1,115 @@
+package syncinator
+
+import (
+	"sync"
+)
+
+type HistoryEventKind int
+
+const (
+	HistoryInvoke HistoryEventKind = iota
+	HistoryComplete
+)
+
+// One end of a metadata operation. The position of an event in the history
+// is its time, an operation happens somewhere between its two events.
+type HistoryEvent struct {
+	Kind     HistoryEventKind
+	OpId     int
+	ClientId int64
+
+	// Set on invoke of an UpdateFile, nil for a GetFileInfoMap
+	Update *FileMetaData
+
+	// Set on complete, the latest version for an UpdateFile or the map for a
+	// GetFileInfoMap. An operation that failed may still have taken effect.
+	Version     int32
+	FileInfoMap map[string]*FileMetaData
+	Err         error
+}
+
+// Records the metadata operations of clients sharing one history
+type History struct {
+	mutex    sync.Mutex
+	events   []HistoryEvent
+	nextOpId int
+}
+
+func NewHistory() *History {
+	return &History{events: make([]HistoryEvent, 0)}
+}
+
+// Returns a copy of the events recorded so far
+func (h *History) Events() []HistoryEvent {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return append([]HistoryEvent(nil), h.events...)
+}
+
+func (h *History) invoke(clientId int64, update *FileMetaData) int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	opId := h.nextOpId
+	h.nextOpId++
+	if update != nil {
+		update = copyFileInfo(update)
+	}
+	h.events = append(h.events, HistoryEvent{Kind: HistoryInvoke, OpId: opId, ClientId: clientId, Update: update})
+	return opId
+}
+
+func (h *History) complete(opId int, clientId int64, version int32, fileInfoMap map[string]*FileMetaData, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	var fileInfoMapCopy map[string]*FileMetaData
+	if fileInfoMap != nil {
+		fileInfoMapCopy = make(map[string]*FileMetaData)
+		for filename, fileMetaData := range fileInfoMap {
+			fileInfoMapCopy[filename] = copyFileInfo(fileMetaData)
+		}
+	}
+	h.events = append(h.events, HistoryEvent{
+		Kind:        HistoryComplete,
+		OpId:        opId,
+		ClientId:    clientId,
+		Version:     version,
+		FileInfoMap: fileInfoMapCopy,
+		Err:         err,
+	})
+}
+
+// An RPCClient that records its metadata operations in a history
+type HistoryClient struct {
+	*RPCClient
+	history *History
+}
+
+func NewHistoryClient(client *RPCClient, history *History) *HistoryClient {
+	return &HistoryClient{RPCClient: client, history: history}
+}
+
+func (c *HistoryClient) GetFileInfoMap(serverFileInfoMap *map[string]*FileMetaData) error {
+	opId := c.history.invoke(c.ClientId, nil)
+	fileInfoMap := make(map[string]*FileMetaData)
+	err := c.RPCClient.GetFileInfoMap(&fileInfoMap)
+	if err == nil && fileInfoMap == nil {
+		// An empty map comes back as nil
+		fileInfoMap = make(map[string]*FileMetaData)
+	}
+	c.history.complete(opId, c.ClientId, 0, fileInfoMap, err)
+	*serverFileInfoMap = fileInfoMap
+	return err
+}
+
+func (c *HistoryClient) UpdateFile(fileMetaData *FileMetaData, latestVersion *int32) error {
+	opId := c.history.invoke(c.ClientId, fileMetaData)
+	var version int32
+	err := c.RPCClient.UpdateFile(fileMetaData, &version)
+	c.history.complete(opId, c.ClientId, version, nil, err)
+	if err == nil && latestVersion != nil {
+		*latestVersion = version
+	}
+	return err
+}
+
+var _ ClientInterface = new(HistoryClient)
@@ -34,6 +34,12 @@ type BlockStoreInterface interface {
 
 	// Get which blocks are on this BlockStore server
 	GetBlockHashes(ctx context.Context, _ *emptypb.Empty) (*BlockHashes, error)
+
+	// Get the blocks of a stream of hashes
+	GetBlocks(stream BlockStore_GetBlocksServer) error
+
+	// Put a stream of blocks
+	PutBlocks(stream BlockStore_PutBlocksServer) error
 }
 
 type ClientInterface interface {
@@ -48,4 +54,6 @@ type ClientInterface interface {
 	PutBlock(block *Block, blockStoreAddr string, succ *bool) error
 	MissingBlocks(blockHashesIn []string, blockStoreAddr string, blockHashesOut *[]string) error
 	GetBlockHashes(blockStoreAddr string, blockHashes *[]string) error
+	GetBlocks(blockHashes []string, blockStoreAddr string, blocks *map[string]*Block) error
+	PutBlocks(blocks []*Block, blockStoreAddr string, succ *bool) error
 }
@@ -0,0 +1,263 @@
+package syncinator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Checks that the history could come from a versioned key/value store that
+// applies every operation atomically between its invoke and complete: an
+// update applies iff its version is one above the current one and returns
+// it, or -1 otherwise, and a read returns the current files.
+//
+// Like Porcupine, the history is checked one file at a time, with reads
+// projected to the file, and each file is searched with the
+// Wing-Gong-Lowe algorithm. A failed or unfinished update may have taken
+// effect at any point after its invoke, a failed read is ignored. Checking
+// files apart misses reads that mix files from different moments.
+func CheckLinearizable(events []HistoryEvent) error {
+	ops := makeHistoryOps(events)
+
+	filenames := make(map[string]bool)
+	for _, op := range ops {
+		if op.update != nil {
+			filenames[op.update.Filename] = true
+		}
+		for filename := range op.fileInfoMap {
+			filenames[filename] = true
+		}
+	}
+	sortedFilenames := make([]string, 0, len(filenames))
+	for filename := range filenames {
+		sortedFilenames = append(sortedFilenames, filename)
+	}
+	sort.Strings(sortedFilenames)
+
+	for _, filename := range sortedFilenames {
+		fileOps := make([]*historyOp, 0)
+		for _, op := range ops {
+			if op.update == nil || op.update.Filename == filename {
+				fileOps = append(fileOps, op)
+			}
+		}
+		if !isLinearizable(fileOps, filename) {
+			return fmt.Errorf("%w: file %s", ErrNotLinearizable, filename)
+		}
+	}
+	return nil
+}
+
+type historyOp struct {
+	call int
+	ret  int
+
+	update      *FileMetaData
+	version     int32
+	fileInfoMap map[string]*FileMetaData
+	// The update failed or never completed
+	isUnknown bool
+}
+
+// Pairs up invoke and complete events into operations
+func makeHistoryOps(events []HistoryEvent) []*historyOp {
+	byId := make(map[int]*historyOp)
+	ops := make([]*historyOp, 0)
+	for time, event := range events {
+		switch event.Kind {
+		case HistoryInvoke:
+			op := &historyOp{call: time, ret: math.MaxInt, update: event.Update, isUnknown: true}
+			byId[event.OpId] = op
+			ops = append(ops, op)
+		case HistoryComplete:
+			op, ok := byId[event.OpId]
+			if !ok || event.Err != nil {
+				continue
+			}
+			op.ret = time
+			op.version = event.Version
+			op.fileInfoMap = event.FileInfoMap
+			op.isUnknown = false
+		}
+	}
+
+	// Drop reads without a result, they observed nothing
+	observed := make([]*historyOp, 0, len(ops))
+	for _, op := range ops {
+		if op.update != nil || !op.isUnknown {
+			observed = append(observed, op)
+		}
+	}
+	return observed
+}
+
+// The state of one file, version 0 if it does not exist
+type fileState struct {
+	version int32
+	hashes  string
+}
+
+func (state fileState) String() string {
+	return fmt.Sprintf("%d:%s", state.version, state.hashes)
+}
+
+func fileStateOf(fileMetaData *FileMetaData) fileState {
+	if fileMetaData == nil {
+		return fileState{}
+	}
+	return fileState{version: fileMetaData.Version, hashes: strings.Join(fileMetaData.BlockHashList, ",")}
+}
+
+// Applies the operation to the state, returns false if its result could not
+// have come from that state
+func stepFile(state fileState, op *historyOp, filename string) (bool, fileState) {
+	if op.update == nil {
+		return fileStateOf(op.fileInfoMap[filename]) == state, state
+	}
+	isApplied := op.update.Version == state.version+1
+	newState := state
+	expectedVersion := int32(-1)
+	if isApplied {
+		newState = fileStateOf(op.update)
+		expectedVersion = op.update.Version
+	}
+	if op.isUnknown {
+		// Only worth linearizing where it takes effect, otherwise it can
+		// always wait until the end
+		return isApplied, newState
+	}
+	return op.version == expectedVersion, newState
+}
+
+// An invoke or complete of an operation in the doubly linked history
+type historyEntry struct {
+	opIdx  int
+	isCall bool
+	match  *historyEntry
+	prev   *historyEntry
+	next   *historyEntry
+}
+
+func (entry *historyEntry) lift() {
+	entry.prev.next = entry.next
+	entry.next.prev = entry.prev
+	match := entry.match
+	match.prev.next = match.next
+	if match.next != nil {
+		match.next.prev = match.prev
+	}
+}
+
+func (entry *historyEntry) unlift() {
+	match := entry.match
+	match.prev.next = match
+	if match.next != nil {
+		match.next.prev = match
+	}
+	entry.prev.next = entry
+	entry.next.prev = entry
+}
+
+// Wing-Gong-Lowe search: repeatedly linearize the first pending operation
+// that fits the state, and backtrack when a complete is reached before its
+// operation is linearized. Visited pairs of linearized set and state are
+// cached, so each is explored once. The search succeeds once every known
+// operation is linearized, the unknown ones then go last.
+func isLinearizable(ops []*historyOp, filename string) bool {
+	numKnown := 0
+	for _, op := range ops {
+		if !op.isUnknown {
+			numKnown++
+		}
+	}
+
+	type timedEntry struct {
+		time  int
+		entry *historyEntry
+	}
+	timed := make([]timedEntry, 0, 2*len(ops))
+	for i, op := range ops {
+		call := &historyEntry{opIdx: i, isCall: true}
+		ret := &historyEntry{opIdx: i}
+		call.match = ret
+		ret.match = call
+		timed = append(timed, timedEntry{time: op.call, entry: call}, timedEntry{time: op.ret, entry: ret})
+	}
+	sort.SliceStable(timed, func(i, j int) bool { return timed[i].time < timed[j].time })
+
+	head := &historyEntry{opIdx: -1}
+	last := head
+	for _, t := range timed {
+		t.entry.prev = last
+		last.next = t.entry
+		last = t.entry
+	}
+
+	type frame struct {
+		entry *historyEntry
+		state fileState
+	}
+	stack := make([]frame, 0)
+	linearized := make(historyBitset, (len(ops)+63)/64)
+	cache := make(map[string]bool)
+	state := fileState{}
+	numKnownLinearized := 0
+
+	entry := head.next
+	for numKnownLinearized < numKnown {
+		if entry.isCall {
+			op := ops[entry.opIdx]
+			ok, newState := stepFile(state, op, filename)
+			if ok {
+				linearized.flip(entry.opIdx)
+				key := linearized.String() + newState.String()
+				if !cache[key] {
+					cache[key] = true
+					stack = append(stack, frame{entry: entry, state: state})
+					state = newState
+					if !op.isUnknown {
+						numKnownLinearized++
+					}
+					entry.lift()
+					entry = head.next
+					continue
+				}
+				linearized.flip(entry.opIdx)
+			}
+			entry = entry.next
+		} else {
+			// The operation completing here cannot be linearized this way
+			if len(stack) == 0 {
+				return false
+			}
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			state = top.state
+			if !ops[top.entry.opIdx].isUnknown {
+				numKnownLinearized--
+			}
+			linearized.flip(top.entry.opIdx)
+			top.entry.unlift()
+			entry = top.entry.next
+		}
+	}
+	return true
+}
+
+// The set of linearized operations
+type historyBitset []uint64
+
+func (b historyBitset) flip(i int) {
+	b[i/64] ^= 1 << (i % 64)
+}
+
+func (b historyBitset) String() string {
+	key := make([]byte, 0, 8*len(b)+1)
+	for _, word := range b {
+		for shift := 0; shift < 64; shift += 8 {
+			key = append(key, byte(word>>shift))
+		}
+	}
+	return string(append(key, '|'))
+}
@@ -3,10 +3,13 @@ package syncinator
 import (
 	context "context"
 	"fmt"
+	"math/rand"
 	"time"
 
 	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -14,10 +17,99 @@ type RPCClient struct {
 	MetaStoreAddrs []string
 	BaseDir        string
 	BlockSize      int
+
+	// Read file metadata from any server, possibly stale
+	ReplicaReads bool
+	// Highest log index observed by replica reads, later reads never go back
+	lastReadIndex int64
+
+	// Identifies this client's updates, so that retries are applied once
+	ClientId int64
+	sequence int64
+
+	// Last server that accepted a request as leader, empty if unknown
+	leaderAddr string
+	// Connections by address, reused across requests
+	conns map[string]*grpc.ClientConn
+}
+
+func (syncClient *RPCClient) getConn(addr string) (*grpc.ClientConn, error) {
+	if conn, ok := syncClient.conns[addr]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	if syncClient.conns == nil {
+		syncClient.conns = make(map[string]*grpc.ClientConn)
+	}
+	syncClient.conns[addr] = conn
+	return conn, nil
+}
+
+// Closes all cached connections
+func (syncClient *RPCClient) Close() error {
+	for addr, conn := range syncClient.conns {
+		conn.Close()
+		delete(syncClient.conns, addr)
+	}
+	return nil
+}
+
+// Sends a request to the leader, starting from the cached one and following
+// the leader hints of servers that reject it. Moves on only from servers that
+// are not the leader or not reachable, any other error is the leader's answer.
+func (syncClient *RPCClient) callLeader(call func(ctx context.Context, c RaftSyncinatorClient) error) error {
+	candidates := make([]string, 0)
+	if syncClient.leaderAddr != "" {
+		candidates = append(candidates, syncClient.leaderAddr)
+	}
+	candidates = append(candidates, syncClient.MetaStoreAddrs...)
+
+	tried := make(map[string]bool)
+	for len(candidates) > 0 {
+		server := candidates[0]
+		candidates = candidates[1:]
+		if tried[server] {
+			continue
+		}
+		tried[server] = true
+
+		conn, err := syncClient.getConn(server)
+		if err != nil {
+			continue
+		}
+
+		c := NewRaftSyncinatorClient(conn)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err = call(ctx, c)
+		cancel()
+		if err == nil {
+			syncClient.leaderAddr = server
+			return nil
+		}
+		switch status.Code(err) {
+		case codes.FailedPrecondition, codes.Unavailable:
+			// Not the leader, or not reachable
+		default:
+			// The leader's own answer
+			return err
+		}
+
+		if syncClient.leaderAddr == server {
+			syncClient.leaderAddr = ""
+		}
+		if leaderHint := LeaderHintFromError(err); leaderHint != nil && leaderHint.LeaderAddr != "" {
+			// Try the hinted leader next
+			candidates = append([]string{leaderHint.LeaderAddr}, candidates...)
+		}
+	}
+	return fmt.Errorf("could not find a leader")
 }
 
 func (syncClient *RPCClient) GetBlock(blockHash string, blockStoreAddr string, block *Block) error {
-	conn, err := grpc.Dial(blockStoreAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := syncClient.getConn(blockStoreAddr)
 	if err != nil {
 		return err
 	}
@@ -27,17 +119,32 @@ func (syncClient *RPCClient) GetBlock(blockHash string, blockStoreAddr string, b
 	defer cancel()
 	b, err := c.GetBlock(ctx, &BlockHash{Hash: blockHash})
 	if err != nil {
-		conn.Close()
-		return err
+		return blockError(err, blockHash)
+	}
+	// The block may also be damaged on its way here
+	if GetBlockHashString(b.BlockData) != blockHash {
+		return fmt.Errorf("%w: %s", ErrBlockCorrupt, blockHash)
 	}
 	block.BlockData = b.BlockData
 	block.BlockSize = b.BlockSize
 
-	return conn.Close()
+	return nil
+}
+
+// Converts the status of a failed block request into ErrBlockNotFound or
+// ErrBlockCorrupt, so that callers can tell them apart
+func blockError(err error, blockHash string) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return fmt.Errorf("%w: %s", ErrBlockNotFound, blockHash)
+	case codes.DataLoss:
+		return fmt.Errorf("%w: %s", ErrBlockCorrupt, blockHash)
+	}
+	return err
 }
 
 func (syncClient *RPCClient) PutBlock(block *Block, blockStoreAddr string, succ *bool) error {
-	conn, err := grpc.Dial(blockStoreAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := syncClient.getConn(blockStoreAddr)
 	if err != nil {
 		return err
 	}
@@ -47,16 +154,15 @@ func (syncClient *RPCClient) PutBlock(block *Block, blockStoreAddr string, succ
 	defer cancel()
 	s, err := c.PutBlock(ctx, block)
 	if err != nil {
-		conn.Close()
 		return err
 	}
 	*succ = s.Flag
 
-	return conn.Close()
+	return nil
 }
 
 func (syncClient *RPCClient) MissingBlocks(blockHashesIn []string, blockStoreAddr string, blockHashesOut *[]string) error {
-	conn, err := grpc.Dial(blockStoreAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := syncClient.getConn(blockStoreAddr)
 	if err != nil {
 		return err
 	}
@@ -66,16 +172,15 @@ func (syncClient *RPCClient) MissingBlocks(blockHashesIn []string, blockStoreAdd
 	defer cancel()
 	h, err := c.MissingBlocks(ctx, &BlockHashes{Hashes: blockHashesIn})
 	if err != nil {
-		conn.Close()
 		return err
 	}
 	*blockHashesOut = h.Hashes
 
-	return conn.Close()
+	return nil
 }
 
 func (syncClient *RPCClient) GetBlockHashes(blockStoreAddr string, blockHashes *[]string) error {
-	conn, err := grpc.Dial(blockStoreAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := syncClient.getConn(blockStoreAddr)
 	if err != nil {
 		return err
 	}
@@ -85,98 +190,179 @@ func (syncClient *RPCClient) GetBlockHashes(blockStoreAddr string, blockHashes *
 	defer cancel()
 	h, err := c.GetBlockHashes(ctx, &emptypb.Empty{})
 	if err != nil {
-		conn.Close()
 		return err
 	}
 	*blockHashes = h.Hashes
 
-	return conn.Close()
+	return nil
 }
 
-func (syncClient *RPCClient) GetFileInfoMap(serverFileInfoMap *map[string]*FileMetaData) error {
-	for _, server := range syncClient.MetaStoreAddrs {
-		conn, err := grpc.Dial(server, grpc.WithTransportCredentials(insecure.NewCredentials()))
-		if err != nil {
-			return err
+// Gets many blocks from one BlockStore over a single stream, keyed by hash
+func (syncClient *RPCClient) GetBlocks(blockHashes []string, blockStoreAddr string, blocks *map[string]*Block) error {
+	conn, err := syncClient.getConn(blockStoreAddr)
+	if err != nil {
+		return err
+	}
+	c := NewBlockStoreClient(conn)
+
+	ctx, touch, cancel := withIdleTimeout(BLOCK_STREAM_IDLE_TIMEOUT)
+	defer cancel()
+	stream, err := c.GetBlocks(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Send the hashes while receiving the blocks, a failed send shows up as an error of Recv
+	go func() {
+		for _, blockHash := range blockHashes {
+			if err := stream.Send(&BlockHash{Hash: blockHash}); err != nil {
+				return
+			}
 		}
+		stream.CloseSend()
+	}()
 
-		c := NewRaftSyncinatorClient(conn)
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-		defer cancel()
-		f, err := c.GetFileInfoMap(ctx, &emptypb.Empty{})
+	received := make(map[string]*Block, len(blockHashes))
+	for _, blockHash := range blockHashes {
+		b, err := stream.Recv()
 		if err != nil {
-			continue
+			return blockError(err, blockHash)
 		}
-		*serverFileInfoMap = f.FileInfoMap
+		touch()
+		// The block may also be damaged on its way here
+		if GetBlockHashString(b.BlockData) != blockHash {
+			return fmt.Errorf("%w: %s", ErrBlockCorrupt, blockHash)
+		}
+		received[blockHash] = b
+	}
+	*blocks = received
+
+	return nil
+}
 
-		return conn.Close()
+// Puts many blocks to one BlockStore over a single stream
+func (syncClient *RPCClient) PutBlocks(blocks []*Block, blockStoreAddr string, succ *bool) error {
+	conn, err := syncClient.getConn(blockStoreAddr)
+	if err != nil {
+		return err
 	}
-	return fmt.Errorf("could not find a leader")
+	c := NewBlockStoreClient(conn)
+
+	ctx, touch, cancel := withIdleTimeout(BLOCK_STREAM_IDLE_TIMEOUT)
+	defer cancel()
+	stream, err := c.PutBlocks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, block := range blocks {
+		// The server's error is returned by CloseAndRecv
+		if err := stream.Send(block); err != nil {
+			break
+		}
+		touch()
+	}
+	s, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	*succ = s.Flag
+
+	return nil
 }
 
-func (syncClient *RPCClient) UpdateFile(fileMetaData *FileMetaData, latestVersion *int32) error {
-	for _, server := range syncClient.MetaStoreAddrs {
-		conn, err := grpc.Dial(server, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// Returns a context that is cancelled once touch has not been called for
+// timeout, so that a long stream only fails when it stops making progress
+func withIdleTimeout(timeout time.Duration) (ctx context.Context, touch func(), cancel func()) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	timer := time.AfterFunc(timeout, cancelCtx)
+	touch = func() {
+		timer.Reset(timeout)
+	}
+	cancel = func() {
+		timer.Stop()
+		cancelCtx()
+	}
+	return ctx, touch, cancel
+}
+
+func (syncClient *RPCClient) GetFileInfoMap(serverFileInfoMap *map[string]*FileMetaData) error {
+	if syncClient.ReplicaReads {
+		return syncClient.getReplicaFileInfoMap(serverFileInfoMap)
+	}
+	return syncClient.callLeader(func(ctx context.Context, c RaftSyncinatorClient) error {
+		f, err := c.GetFileInfoMap(ctx, &emptypb.Empty{})
 		if err != nil {
 			return err
 		}
+		*serverFileInfoMap = f.FileInfoMap
+		return nil
+	})
+}
+
+func (syncClient *RPCClient) getReplicaFileInfoMap(serverFileInfoMap *map[string]*FileMetaData) error {
+	// Spread reads across servers
+	offset := rand.Intn(len(syncClient.MetaStoreAddrs))
+	for i := range syncClient.MetaStoreAddrs {
+		server := syncClient.MetaStoreAddrs[(offset+i)%len(syncClient.MetaStoreAddrs)]
+		conn, err := syncClient.getConn(server)
+		if err != nil {
+			continue
+		}
 
 		c := NewRaftSyncinatorClient(conn)
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-		defer cancel()
-		l, err := c.UpdateFile(ctx, fileMetaData)
+		f, err := c.GetReplicaFileInfoMap(ctx, &ReplicaReadInput{MinIndex: syncClient.lastReadIndex})
+		cancel()
 		if err != nil {
 			continue
 		}
-		*latestVersion = l.Version
+		*serverFileInfoMap = f.FileInfoMap.GetFileInfoMap()
+		syncClient.lastReadIndex = max(syncClient.lastReadIndex, f.LastApplied)
 
-		return conn.Close()
+		return nil
 	}
-	return fmt.Errorf("could not find a leader")
+	return fmt.Errorf("could not find an up-to-date replica")
 }
 
-func (syncClient *RPCClient) GetBlockStoreMap(blockHashesIn []string, blockStoreMap *map[string][]string) error {
-	for _, server := range syncClient.MetaStoreAddrs {
-		conn, err := grpc.Dial(server, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func (syncClient *RPCClient) UpdateFile(fileMetaData *FileMetaData, latestVersion *int32) error {
+	// Every retry carries the same sequence number
+	syncClient.sequence++
+	request := copyFileInfo(fileMetaData)
+	request.ClientId = syncClient.ClientId
+	request.Sequence = syncClient.sequence
+
+	return syncClient.callLeader(func(ctx context.Context, c RaftSyncinatorClient) error {
+		l, err := c.UpdateFile(ctx, request)
 		if err != nil {
 			return err
 		}
+		*latestVersion = l.Version
+		return nil
+	})
+}
 
-		c := NewRaftSyncinatorClient(conn)
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-		defer cancel()
+func (syncClient *RPCClient) GetBlockStoreMap(blockHashesIn []string, blockStoreMap *map[string][]string) error {
+	return syncClient.callLeader(func(ctx context.Context, c RaftSyncinatorClient) error {
 		b, err := c.GetBlockStoreMap(ctx, &BlockHashes{Hashes: blockHashesIn})
 		if err != nil {
-			continue
+			return err
 		}
 		for k, v := range b.BlockStoreMap {
 			(*blockStoreMap)[k] = v.Hashes
 		}
-
-		return conn.Close()
-	}
-	return fmt.Errorf("could not find a leader")
+		return nil
+	})
 }
 
 func (syncClient *RPCClient) GetBlockStoreAddrs(blockStoreAddrs *[]string) error {
-	for _, server := range syncClient.MetaStoreAddrs {
-		conn, err := grpc.Dial(server, grpc.WithTransportCredentials(insecure.NewCredentials()))
-		if err != nil {
-			return err
-		}
-
-		c := NewRaftSyncinatorClient(conn)
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-		defer cancel()
+	return syncClient.callLeader(func(ctx context.Context, c RaftSyncinatorClient) error {
 		b, err := c.GetBlockStoreAddrs(ctx, &emptypb.Empty{})
 		if err != nil {
-			continue
+			return err
 		}
 		*blockStoreAddrs = b.BlockStoreAddrs
-
-		return conn.Close()
-	}
-	return fmt.Errorf("could not find a leader")
+		return nil
+	})
 }
 
 // This line guarantees all method for RPCClient are implemented
@@ -188,5 +374,8 @@ func NewSyncinatorRPCClient(addrs []string, baseDir string, blockSize int) RPCCl
 		MetaStoreAddrs: addrs,
 		BaseDir:        baseDir,
 		BlockSize:      blockSize,
+		lastReadIndex:  -1,
+		ClientId:       rand.Int63(),
+		conns:          make(map[string]*grpc.ClientConn),
 	}
 }
@@ -189,16 +189,28 @@ func (logic *Logic) DownloadFile(filename string) error {
 		// Download file
 		blockStoreMap := make(map[string][]string)
 		// Get responsible server for each block
-		logic.RPCClient.GetBlockStoreMap(remoteBlockHashList, &blockStoreMap)
-		hashAddrMap := invertBlockStoreMap(blockStoreMap)
+		if err := logic.RPCClient.GetBlockStoreMap(remoteBlockHashList, &blockStoreMap); err != nil {
+			return err
+		}
 
+		// Every block is fetched and verified before the local file is touched,
+		// the blocks of each server over one stream
+		blocks := make(map[string]*Block)
+		for addr, blockHashList := range blockStoreMap {
+			serverBlocks := make(map[string]*Block)
+			err := logic.RPCClient.GetBlocks(blockHashList, addr, &serverBlocks)
+			if err != nil {
+				return fmt.Errorf("could not download %s: %w", filename, err)
+			}
+			for blockHash, block := range serverBlocks {
+				blocks[blockHash] = block
+			}
+		}
 		fileData := []byte{}
 		for _, blockHash := range remoteBlockHashList {
-			// Get each block from its responsible server
-			var block Block
-			err := logic.RPCClient.GetBlock(blockHash, hashAddrMap[blockHash], &block)
-			if err != nil {
-				return err
+			block, ok := blocks[blockHash]
+			if !ok {
+				return fmt.Errorf("could not download %s: %w: %s", filename, ErrBlockNotFound, blockHash)
 			}
 			fileData = append(fileData, block.BlockData...)
 		}
@@ -304,7 +316,9 @@ func (logic *Logic) UploadFile(filename string) error {
 		}
 	} else {
 		blockStoreMap := make(map[string][]string)
-		logic.RPCClient.GetBlockStoreMap(baseBlockHashList, &blockStoreMap)
+		if err := logic.RPCClient.GetBlockStoreMap(baseBlockHashList, &blockStoreMap); err != nil {
+			return err
+		}
 		hashAddrMap := invertBlockStoreMap(blockStoreMap)
 
 		// Get missing blocks
@@ -324,10 +338,13 @@ func (logic *Logic) UploadFile(filename string) error {
 		if err != nil {
 			return err
 		}
+		// Only upload missing blocks, the blocks of each server over one stream
+		uploads := make(map[string][]*Block)
 		for i := range baseBlockHashList {
 			blockHash := baseBlockHashList[i]
-			// Only upload missing blocks
 			if _, missing := missingBlockHashSet[blockHash]; missing {
+				// A block that repeats within the file is uploaded once
+				delete(missingBlockHashSet, blockHash)
 				end := (i + 1) * logic.RPCClient.BlockSize
 				if end > len(fileData) {
 					end = len(fileData)
@@ -336,14 +353,19 @@ func (logic *Logic) UploadFile(filename string) error {
 				var block Block
 				block.BlockData = blockData
 				block.BlockSize = int32(len(blockData))
-				var succ bool
-				err := logic.RPCClient.PutBlock(&block, hashAddrMap[blockHash], &succ)
-				if err != nil {
-					return err
-				}
-				if !succ {
-					return fmt.Errorf("put block failed")
-				}
+				block.ExpectedHash = blockHash
+				addr := hashAddrMap[blockHash]
+				uploads[addr] = append(uploads[addr], &block)
+			}
+		}
+		for addr, blocks := range uploads {
+			var succ bool
+			err := logic.RPCClient.PutBlocks(blocks, addr, &succ)
+			if err != nil {
+				return err
+			}
+			if !succ {
+				return fmt.Errorf("put block failed")
 			}
 		}
 
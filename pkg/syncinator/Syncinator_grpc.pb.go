@@ -27,6 +27,11 @@ type BlockStoreClient interface {
 	PutBlock(ctx context.Context, in *Block, opts ...grpc.CallOption) (*Success, error)
 	MissingBlocks(ctx context.Context, in *BlockHashes, opts ...grpc.CallOption) (*BlockHashes, error)
 	GetBlockHashes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BlockHashes, error)
+	// Sends back the block of every hash received, in order, and ends the
+	// stream at the first block that is missing or corrupt
+	GetBlocks(ctx context.Context, opts ...grpc.CallOption) (BlockStore_GetBlocksClient, error)
+	// Stores every block received, and ends the stream at the first invalid one
+	PutBlocks(ctx context.Context, opts ...grpc.CallOption) (BlockStore_PutBlocksClient, error)
 }
 
 type blockStoreClient struct {
@@ -73,6 +78,71 @@ func (c *blockStoreClient) GetBlockHashes(ctx context.Context, in *emptypb.Empty
 	return out, nil
 }
 
+func (c *blockStoreClient) GetBlocks(ctx context.Context, opts ...grpc.CallOption) (BlockStore_GetBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BlockStore_ServiceDesc.Streams[0], "/syncinator.BlockStore/GetBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockStoreGetBlocksClient{stream}
+	return x, nil
+}
+
+type BlockStore_GetBlocksClient interface {
+	Send(*BlockHash) error
+	Recv() (*Block, error)
+	grpc.ClientStream
+}
+
+type blockStoreGetBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockStoreGetBlocksClient) Send(m *BlockHash) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *blockStoreGetBlocksClient) Recv() (*Block, error) {
+	m := new(Block)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blockStoreClient) PutBlocks(ctx context.Context, opts ...grpc.CallOption) (BlockStore_PutBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BlockStore_ServiceDesc.Streams[1], "/syncinator.BlockStore/PutBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockStorePutBlocksClient{stream}
+	return x, nil
+}
+
+type BlockStore_PutBlocksClient interface {
+	Send(*Block) error
+	CloseAndRecv() (*Success, error)
+	grpc.ClientStream
+}
+
+type blockStorePutBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockStorePutBlocksClient) Send(m *Block) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *blockStorePutBlocksClient) CloseAndRecv() (*Success, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Success)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BlockStoreServer is the server API for BlockStore service.
 // All implementations must embed UnimplementedBlockStoreServer
 // for forward compatibility
@@ -81,6 +151,11 @@ type BlockStoreServer interface {
 	PutBlock(context.Context, *Block) (*Success, error)
 	MissingBlocks(context.Context, *BlockHashes) (*BlockHashes, error)
 	GetBlockHashes(context.Context, *emptypb.Empty) (*BlockHashes, error)
+	// Sends back the block of every hash received, in order, and ends the
+	// stream at the first block that is missing or corrupt
+	GetBlocks(BlockStore_GetBlocksServer) error
+	// Stores every block received, and ends the stream at the first invalid one
+	PutBlocks(BlockStore_PutBlocksServer) error
 	mustEmbedUnimplementedBlockStoreServer()
 }
 
@@ -100,6 +175,12 @@ func (UnimplementedBlockStoreServer) MissingBlocks(context.Context, *BlockHashes
 func (UnimplementedBlockStoreServer) GetBlockHashes(context.Context, *emptypb.Empty) (*BlockHashes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlockHashes not implemented")
 }
+func (UnimplementedBlockStoreServer) GetBlocks(BlockStore_GetBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetBlocks not implemented")
+}
+func (UnimplementedBlockStoreServer) PutBlocks(BlockStore_PutBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method PutBlocks not implemented")
+}
 func (UnimplementedBlockStoreServer) mustEmbedUnimplementedBlockStoreServer() {}
 
 // UnsafeBlockStoreServer may be embedded to opt out of forward compatibility for this service.
@@ -185,6 +266,58 @@ func _BlockStore_GetBlockHashes_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockStore_GetBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BlockStoreServer).GetBlocks(&blockStoreGetBlocksServer{stream})
+}
+
+type BlockStore_GetBlocksServer interface {
+	Send(*Block) error
+	Recv() (*BlockHash, error)
+	grpc.ServerStream
+}
+
+type blockStoreGetBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockStoreGetBlocksServer) Send(m *Block) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *blockStoreGetBlocksServer) Recv() (*BlockHash, error) {
+	m := new(BlockHash)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _BlockStore_PutBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BlockStoreServer).PutBlocks(&blockStorePutBlocksServer{stream})
+}
+
+type BlockStore_PutBlocksServer interface {
+	SendAndClose(*Success) error
+	Recv() (*Block, error)
+	grpc.ServerStream
+}
+
+type blockStorePutBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockStorePutBlocksServer) SendAndClose(m *Success) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *blockStorePutBlocksServer) Recv() (*Block, error) {
+	m := new(Block)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BlockStore_ServiceDesc is the grpc.ServiceDesc for BlockStore service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -209,7 +342,19 @@ var BlockStore_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _BlockStore_GetBlockHashes_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetBlocks",
+			Handler:       _BlockStore_GetBlocks_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PutBlocks",
+			Handler:       _BlockStore_PutBlocks_Handler,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "pkg/syncinator/SyncStore.proto",
 }
 
@@ -413,18 +558,33 @@ var MetaStore_ServiceDesc = grpc.ServiceDesc{
 type RaftSyncinatorClient interface {
 	// raft
 	AppendEntries(ctx context.Context, in *AppendEntryInput, opts ...grpc.CallOption) (*AppendEntryOutput, error)
+	RequestVote(ctx context.Context, in *RequestVoteInput, opts ...grpc.CallOption) (*RequestVoteOutput, error)
+	InstallSnapshot(ctx context.Context, in *InstallSnapshotInput, opts ...grpc.CallOption) (*InstallSnapshotOutput, error)
 	SetLeader(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Success, error)
 	SendHeartbeat(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Success, error)
+	TransferLeadership(ctx context.Context, in *TransferLeadershipInput, opts ...grpc.CallOption) (*Success, error)
+	TimeoutNow(ctx context.Context, in *TimeoutNowInput, opts ...grpc.CallOption) (*Success, error)
+	// membership
+	AddServer(ctx context.Context, in *RaftMember, opts ...grpc.CallOption) (*Success, error)
+	RemoveServer(ctx context.Context, in *RaftMember, opts ...grpc.CallOption) (*Success, error)
+	PromoteLearner(ctx context.Context, in *RaftMember, opts ...grpc.CallOption) (*Success, error)
 	// metastore
 	GetFileInfoMap(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FileInfoMap, error)
 	UpdateFile(ctx context.Context, in *FileMetaData, opts ...grpc.CallOption) (*Version, error)
 	GetBlockStoreMap(ctx context.Context, in *BlockHashes, opts ...grpc.CallOption) (*BlockStoreMap, error)
 	GetBlockStoreAddrs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BlockStoreAddrs, error)
+	GetReplicaFileInfoMap(ctx context.Context, in *ReplicaReadInput, opts ...grpc.CallOption) (*ReplicaFileInfoMap, error)
+	// inspection
+	GetRaftStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RaftStatus, error)
+	GetLogEntries(ctx context.Context, in *LogRange, opts ...grpc.CallOption) (*LogEntries, error)
 	// testing interface
 	GetInternalState(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RaftInternalState, error)
 	Restore(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Success, error)
 	Crash(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Success, error)
 	MakeServerUnreachableFrom(ctx context.Context, in *UnreachableFromServers, opts ...grpc.CallOption) (*Success, error)
+	SetLinkFaults(ctx context.Context, in *LinkFaults, opts ...grpc.CallOption) (*Success, error)
+	Pause(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Success, error)
+	Resume(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Success, error)
 }
 
 type raftSyncinatorClient struct {
@@ -444,6 +604,24 @@ func (c *raftSyncinatorClient) AppendEntries(ctx context.Context, in *AppendEntr
 	return out, nil
 }
 
+func (c *raftSyncinatorClient) RequestVote(ctx context.Context, in *RequestVoteInput, opts ...grpc.CallOption) (*RequestVoteOutput, error) {
+	out := new(RequestVoteOutput)
+	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/RequestVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftSyncinatorClient) InstallSnapshot(ctx context.Context, in *InstallSnapshotInput, opts ...grpc.CallOption) (*InstallSnapshotOutput, error) {
+	out := new(InstallSnapshotOutput)
+	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/InstallSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *raftSyncinatorClient) SetLeader(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Success, error) {
 	out := new(Success)
 	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/SetLeader", in, out, opts...)
@@ -462,6 +640,51 @@ func (c *raftSyncinatorClient) SendHeartbeat(ctx context.Context, in *emptypb.Em
 	return out, nil
 }
 
+func (c *raftSyncinatorClient) TransferLeadership(ctx context.Context, in *TransferLeadershipInput, opts ...grpc.CallOption) (*Success, error) {
+	out := new(Success)
+	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/TransferLeadership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftSyncinatorClient) TimeoutNow(ctx context.Context, in *TimeoutNowInput, opts ...grpc.CallOption) (*Success, error) {
+	out := new(Success)
+	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/TimeoutNow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftSyncinatorClient) AddServer(ctx context.Context, in *RaftMember, opts ...grpc.CallOption) (*Success, error) {
+	out := new(Success)
+	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/AddServer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftSyncinatorClient) RemoveServer(ctx context.Context, in *RaftMember, opts ...grpc.CallOption) (*Success, error) {
+	out := new(Success)
+	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/RemoveServer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftSyncinatorClient) PromoteLearner(ctx context.Context, in *RaftMember, opts ...grpc.CallOption) (*Success, error) {
+	out := new(Success)
+	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/PromoteLearner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *raftSyncinatorClient) GetFileInfoMap(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FileInfoMap, error) {
 	out := new(FileInfoMap)
 	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/GetFileInfoMap", in, out, opts...)
@@ -498,6 +721,33 @@ func (c *raftSyncinatorClient) GetBlockStoreAddrs(ctx context.Context, in *empty
 	return out, nil
 }
 
+func (c *raftSyncinatorClient) GetReplicaFileInfoMap(ctx context.Context, in *ReplicaReadInput, opts ...grpc.CallOption) (*ReplicaFileInfoMap, error) {
+	out := new(ReplicaFileInfoMap)
+	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/GetReplicaFileInfoMap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftSyncinatorClient) GetRaftStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RaftStatus, error) {
+	out := new(RaftStatus)
+	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/GetRaftStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftSyncinatorClient) GetLogEntries(ctx context.Context, in *LogRange, opts ...grpc.CallOption) (*LogEntries, error) {
+	out := new(LogEntries)
+	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/GetLogEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *raftSyncinatorClient) GetInternalState(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RaftInternalState, error) {
 	out := new(RaftInternalState)
 	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/GetInternalState", in, out, opts...)
@@ -534,24 +784,66 @@ func (c *raftSyncinatorClient) MakeServerUnreachableFrom(ctx context.Context, in
 	return out, nil
 }
 
+func (c *raftSyncinatorClient) SetLinkFaults(ctx context.Context, in *LinkFaults, opts ...grpc.CallOption) (*Success, error) {
+	out := new(Success)
+	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/SetLinkFaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftSyncinatorClient) Pause(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Success, error) {
+	out := new(Success)
+	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/Pause", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftSyncinatorClient) Resume(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Success, error) {
+	out := new(Success)
+	err := c.cc.Invoke(ctx, "/syncinator.RaftSyncinator/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RaftSyncinatorServer is the server API for RaftSyncinator service.
 // All implementations must embed UnimplementedRaftSyncinatorServer
 // for forward compatibility
 type RaftSyncinatorServer interface {
 	// raft
 	AppendEntries(context.Context, *AppendEntryInput) (*AppendEntryOutput, error)
+	RequestVote(context.Context, *RequestVoteInput) (*RequestVoteOutput, error)
+	InstallSnapshot(context.Context, *InstallSnapshotInput) (*InstallSnapshotOutput, error)
 	SetLeader(context.Context, *emptypb.Empty) (*Success, error)
 	SendHeartbeat(context.Context, *emptypb.Empty) (*Success, error)
+	TransferLeadership(context.Context, *TransferLeadershipInput) (*Success, error)
+	TimeoutNow(context.Context, *TimeoutNowInput) (*Success, error)
+	// membership
+	AddServer(context.Context, *RaftMember) (*Success, error)
+	RemoveServer(context.Context, *RaftMember) (*Success, error)
+	PromoteLearner(context.Context, *RaftMember) (*Success, error)
 	// metastore
 	GetFileInfoMap(context.Context, *emptypb.Empty) (*FileInfoMap, error)
 	UpdateFile(context.Context, *FileMetaData) (*Version, error)
 	GetBlockStoreMap(context.Context, *BlockHashes) (*BlockStoreMap, error)
 	GetBlockStoreAddrs(context.Context, *emptypb.Empty) (*BlockStoreAddrs, error)
+	GetReplicaFileInfoMap(context.Context, *ReplicaReadInput) (*ReplicaFileInfoMap, error)
+	// inspection
+	GetRaftStatus(context.Context, *emptypb.Empty) (*RaftStatus, error)
+	GetLogEntries(context.Context, *LogRange) (*LogEntries, error)
 	// testing interface
 	GetInternalState(context.Context, *emptypb.Empty) (*RaftInternalState, error)
 	Restore(context.Context, *emptypb.Empty) (*Success, error)
 	Crash(context.Context, *emptypb.Empty) (*Success, error)
 	MakeServerUnreachableFrom(context.Context, *UnreachableFromServers) (*Success, error)
+	SetLinkFaults(context.Context, *LinkFaults) (*Success, error)
+	Pause(context.Context, *emptypb.Empty) (*Success, error)
+	Resume(context.Context, *emptypb.Empty) (*Success, error)
 	mustEmbedUnimplementedRaftSyncinatorServer()
 }
 
@@ -562,12 +854,33 @@ type UnimplementedRaftSyncinatorServer struct {
 func (UnimplementedRaftSyncinatorServer) AppendEntries(context.Context, *AppendEntryInput) (*AppendEntryOutput, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AppendEntries not implemented")
 }
+func (UnimplementedRaftSyncinatorServer) RequestVote(context.Context, *RequestVoteInput) (*RequestVoteOutput, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestVote not implemented")
+}
+func (UnimplementedRaftSyncinatorServer) InstallSnapshot(context.Context, *InstallSnapshotInput) (*InstallSnapshotOutput, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstallSnapshot not implemented")
+}
 func (UnimplementedRaftSyncinatorServer) SetLeader(context.Context, *emptypb.Empty) (*Success, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetLeader not implemented")
 }
 func (UnimplementedRaftSyncinatorServer) SendHeartbeat(context.Context, *emptypb.Empty) (*Success, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendHeartbeat not implemented")
 }
+func (UnimplementedRaftSyncinatorServer) TransferLeadership(context.Context, *TransferLeadershipInput) (*Success, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferLeadership not implemented")
+}
+func (UnimplementedRaftSyncinatorServer) TimeoutNow(context.Context, *TimeoutNowInput) (*Success, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TimeoutNow not implemented")
+}
+func (UnimplementedRaftSyncinatorServer) AddServer(context.Context, *RaftMember) (*Success, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddServer not implemented")
+}
+func (UnimplementedRaftSyncinatorServer) RemoveServer(context.Context, *RaftMember) (*Success, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveServer not implemented")
+}
+func (UnimplementedRaftSyncinatorServer) PromoteLearner(context.Context, *RaftMember) (*Success, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteLearner not implemented")
+}
 func (UnimplementedRaftSyncinatorServer) GetFileInfoMap(context.Context, *emptypb.Empty) (*FileInfoMap, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFileInfoMap not implemented")
 }
@@ -580,6 +893,15 @@ func (UnimplementedRaftSyncinatorServer) GetBlockStoreMap(context.Context, *Bloc
 func (UnimplementedRaftSyncinatorServer) GetBlockStoreAddrs(context.Context, *emptypb.Empty) (*BlockStoreAddrs, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlockStoreAddrs not implemented")
 }
+func (UnimplementedRaftSyncinatorServer) GetReplicaFileInfoMap(context.Context, *ReplicaReadInput) (*ReplicaFileInfoMap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplicaFileInfoMap not implemented")
+}
+func (UnimplementedRaftSyncinatorServer) GetRaftStatus(context.Context, *emptypb.Empty) (*RaftStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRaftStatus not implemented")
+}
+func (UnimplementedRaftSyncinatorServer) GetLogEntries(context.Context, *LogRange) (*LogEntries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogEntries not implemented")
+}
 func (UnimplementedRaftSyncinatorServer) GetInternalState(context.Context, *emptypb.Empty) (*RaftInternalState, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInternalState not implemented")
 }
@@ -592,6 +914,15 @@ func (UnimplementedRaftSyncinatorServer) Crash(context.Context, *emptypb.Empty)
 func (UnimplementedRaftSyncinatorServer) MakeServerUnreachableFrom(context.Context, *UnreachableFromServers) (*Success, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MakeServerUnreachableFrom not implemented")
 }
+func (UnimplementedRaftSyncinatorServer) SetLinkFaults(context.Context, *LinkFaults) (*Success, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLinkFaults not implemented")
+}
+func (UnimplementedRaftSyncinatorServer) Pause(context.Context, *emptypb.Empty) (*Success, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (UnimplementedRaftSyncinatorServer) Resume(context.Context, *emptypb.Empty) (*Success, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
 func (UnimplementedRaftSyncinatorServer) mustEmbedUnimplementedRaftSyncinatorServer() {}
 
 // UnsafeRaftSyncinatorServer may be embedded to opt out of forward compatibility for this service.
@@ -623,6 +954,42 @@ func _RaftSyncinator_AppendEntries_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RaftSyncinator_RequestVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestVoteInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftSyncinatorServer).RequestVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/syncinator.RaftSyncinator/RequestVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftSyncinatorServer).RequestVote(ctx, req.(*RequestVoteInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftSyncinator_InstallSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstallSnapshotInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftSyncinatorServer).InstallSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/syncinator.RaftSyncinator/InstallSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftSyncinatorServer).InstallSnapshot(ctx, req.(*InstallSnapshotInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RaftSyncinator_SetLeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -659,6 +1026,96 @@ func _RaftSyncinator_SendHeartbeat_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RaftSyncinator_TransferLeadership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferLeadershipInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftSyncinatorServer).TransferLeadership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/syncinator.RaftSyncinator/TransferLeadership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftSyncinatorServer).TransferLeadership(ctx, req.(*TransferLeadershipInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftSyncinator_TimeoutNow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeoutNowInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftSyncinatorServer).TimeoutNow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/syncinator.RaftSyncinator/TimeoutNow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftSyncinatorServer).TimeoutNow(ctx, req.(*TimeoutNowInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftSyncinator_AddServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaftMember)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftSyncinatorServer).AddServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/syncinator.RaftSyncinator/AddServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftSyncinatorServer).AddServer(ctx, req.(*RaftMember))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftSyncinator_RemoveServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaftMember)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftSyncinatorServer).RemoveServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/syncinator.RaftSyncinator/RemoveServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftSyncinatorServer).RemoveServer(ctx, req.(*RaftMember))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftSyncinator_PromoteLearner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaftMember)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftSyncinatorServer).PromoteLearner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/syncinator.RaftSyncinator/PromoteLearner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftSyncinatorServer).PromoteLearner(ctx, req.(*RaftMember))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RaftSyncinator_GetFileInfoMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -731,6 +1188,60 @@ func _RaftSyncinator_GetBlockStoreAddrs_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RaftSyncinator_GetReplicaFileInfoMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicaReadInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftSyncinatorServer).GetReplicaFileInfoMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/syncinator.RaftSyncinator/GetReplicaFileInfoMap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftSyncinatorServer).GetReplicaFileInfoMap(ctx, req.(*ReplicaReadInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftSyncinator_GetRaftStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftSyncinatorServer).GetRaftStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/syncinator.RaftSyncinator/GetRaftStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftSyncinatorServer).GetRaftStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftSyncinator_GetLogEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogRange)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftSyncinatorServer).GetLogEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/syncinator.RaftSyncinator/GetLogEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftSyncinatorServer).GetLogEntries(ctx, req.(*LogRange))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RaftSyncinator_GetInternalState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -803,6 +1314,60 @@ func _RaftSyncinator_MakeServerUnreachableFrom_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RaftSyncinator_SetLinkFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkFaults)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftSyncinatorServer).SetLinkFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/syncinator.RaftSyncinator/SetLinkFaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftSyncinatorServer).SetLinkFaults(ctx, req.(*LinkFaults))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftSyncinator_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftSyncinatorServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/syncinator.RaftSyncinator/Pause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftSyncinatorServer).Pause(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftSyncinator_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftSyncinatorServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/syncinator.RaftSyncinator/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftSyncinatorServer).Resume(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RaftSyncinator_ServiceDesc is the grpc.ServiceDesc for RaftSyncinator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -814,6 +1379,14 @@ var RaftSyncinator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AppendEntries",
 			Handler:    _RaftSyncinator_AppendEntries_Handler,
 		},
+		{
+			MethodName: "RequestVote",
+			Handler:    _RaftSyncinator_RequestVote_Handler,
+		},
+		{
+			MethodName: "InstallSnapshot",
+			Handler:    _RaftSyncinator_InstallSnapshot_Handler,
+		},
 		{
 			MethodName: "SetLeader",
 			Handler:    _RaftSyncinator_SetLeader_Handler,
@@ -822,6 +1395,26 @@ var RaftSyncinator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendHeartbeat",
 			Handler:    _RaftSyncinator_SendHeartbeat_Handler,
 		},
+		{
+			MethodName: "TransferLeadership",
+			Handler:    _RaftSyncinator_TransferLeadership_Handler,
+		},
+		{
+			MethodName: "TimeoutNow",
+			Handler:    _RaftSyncinator_TimeoutNow_Handler,
+		},
+		{
+			MethodName: "AddServer",
+			Handler:    _RaftSyncinator_AddServer_Handler,
+		},
+		{
+			MethodName: "RemoveServer",
+			Handler:    _RaftSyncinator_RemoveServer_Handler,
+		},
+		{
+			MethodName: "PromoteLearner",
+			Handler:    _RaftSyncinator_PromoteLearner_Handler,
+		},
 		{
 			MethodName: "GetFileInfoMap",
 			Handler:    _RaftSyncinator_GetFileInfoMap_Handler,
@@ -838,6 +1431,18 @@ var RaftSyncinator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlockStoreAddrs",
 			Handler:    _RaftSyncinator_GetBlockStoreAddrs_Handler,
 		},
+		{
+			MethodName: "GetReplicaFileInfoMap",
+			Handler:    _RaftSyncinator_GetReplicaFileInfoMap_Handler,
+		},
+		{
+			MethodName: "GetRaftStatus",
+			Handler:    _RaftSyncinator_GetRaftStatus_Handler,
+		},
+		{
+			MethodName: "GetLogEntries",
+			Handler:    _RaftSyncinator_GetLogEntries_Handler,
+		},
 		{
 			MethodName: "GetInternalState",
 			Handler:    _RaftSyncinator_GetInternalState_Handler,
@@ -854,6 +1459,18 @@ var RaftSyncinator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MakeServerUnreachableFrom",
 			Handler:    _RaftSyncinator_MakeServerUnreachableFrom_Handler,
 		},
+		{
+			MethodName: "SetLinkFaults",
+			Handler:    _RaftSyncinator_SetLinkFaults_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _RaftSyncinator_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _RaftSyncinator_Resume_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/syncinator/SyncStore.proto",
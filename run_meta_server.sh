@@ -1,6 +1,6 @@
 # Start metastore servers
-go run cmd/SyncinatorRaftServerExec/main.go -f config.json -i 0 &
-go run cmd/SyncinatorRaftServerExec/main.go -f config.json -i 1 &
-go run cmd/SyncinatorRaftServerExec/main.go -f config.json -i 2 &
+go run cmd/SyncinatorRaftServerExec/main.go -f config.json -i 0 -dir raft_data &
+go run cmd/SyncinatorRaftServerExec/main.go -f config.json -i 1 -dir raft_data &
+go run cmd/SyncinatorRaftServerExec/main.go -f config.json -i 2 -dir raft_data &
 
 wait
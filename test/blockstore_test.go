@@ -0,0 +1,342 @@
+package SyncTest
+
+import (
+	"context"
+	"cse224/proj5/pkg/syncinator"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestDiskBlockStorageRecovers(t *testing.T) {
+	dir := t.TempDir()
+	storage, err := syncinator.NewDiskBlockStorage(dir)
+	if err != nil {
+		t.Fatalf("could not open storage: %v", err)
+	}
+
+	blocks := make(map[string][]byte)
+	for i := 0; i < 20; i++ {
+		data := []byte(fmt.Sprintf("block data %d", i))
+		hash := syncinator.GetBlockHashString(data)
+		if err := storage.Put(hash, &syncinator.Block{BlockData: data, BlockSize: int32(len(data))}); err != nil {
+			t.Fatalf("put failed: %v", err)
+		}
+		blocks[hash] = data
+	}
+
+	// A write cut short by a crash leaves a temp file behind
+	var someHash string
+	for hash := range blocks {
+		someHash = hash
+		break
+	}
+	tmpPath := filepath.Join(dir, someHash[:2], "0123"+syncinator.TEMP_FILE_SUFFIX)
+	if err := os.WriteFile(tmpPath, []byte("partial"), 0644); err != nil {
+		t.Fatalf("could not write temp file: %v", err)
+	}
+
+	storage, err = syncinator.NewDiskBlockStorage(dir)
+	if err != nil {
+		t.Fatalf("could not reopen storage: %v", err)
+	}
+	if _, err := os.Stat(tmpPath); !os.IsNotExist(err) {
+		t.Fatalf("temp file was not removed: %v", err)
+	}
+
+	hashes := storage.Hashes()
+	if len(hashes) != len(blocks) {
+		t.Fatalf("expected %d blocks after restart, got %d", len(blocks), len(hashes))
+	}
+	for hash, data := range blocks {
+		block, ok, err := storage.Get(hash)
+		if err != nil || !ok {
+			t.Fatalf("block %s lost after restart: %v", hash, err)
+		}
+		if string(block.BlockData) != string(data) || block.BlockSize != int32(len(data)) {
+			t.Fatalf("block %s changed after restart", hash)
+		}
+	}
+
+	// Hashes that are not block names never reach the file system
+	for _, hash := range []string{"../" + someHash, someHash[:2], "", someHash + "0"} {
+		if _, ok, err := storage.Get(hash); ok || err != nil {
+			t.Fatalf("found block for invalid hash %q: %v", hash, err)
+		}
+	}
+}
+
+func TestBlockStoreGetBlockErrors(t *testing.T) {
+	dir := t.TempDir()
+	storage, err := syncinator.NewDiskBlockStorage(dir)
+	if err != nil {
+		t.Fatalf("could not open storage: %v", err)
+	}
+	blockStore := syncinator.NewBlockStoreWithStorage(storage)
+	ctx := context.Background()
+
+	unknown := syncinator.GetBlockHashString([]byte("never uploaded"))
+	if _, err := blockStore.GetBlock(ctx, &syncinator.BlockHash{Hash: unknown}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for an unknown block, got %v", err)
+	}
+
+	data := []byte("block data")
+	hash := syncinator.GetBlockHashString(data)
+	if _, err := blockStore.PutBlock(ctx, &syncinator.Block{BlockData: data, BlockSize: int32(len(data))}); err != nil {
+		t.Fatalf("put failed: %v", err)
+	}
+
+	// Damage the block on disk
+	if err := os.WriteFile(filepath.Join(dir, hash[:2], hash), []byte("block dat4"), 0644); err != nil {
+		t.Fatalf("could not overwrite block: %v", err)
+	}
+	if _, err := blockStore.GetBlock(ctx, &syncinator.BlockHash{Hash: hash}); status.Code(err) != codes.DataLoss {
+		t.Fatalf("expected DataLoss for a corrupt block, got %v", err)
+	}
+}
+
+func TestBlockStorePutBlockValidation(t *testing.T) {
+	blockStore := syncinator.NewBlockStore()
+	blockStore.MaxBlockSize = 16
+	ctx := context.Background()
+
+	data := []byte("block data")
+	hash := syncinator.GetBlockHashString(data)
+	invalid := map[string]*syncinator.Block{
+		"too large":     {BlockData: []byte("more than sixteen bytes"), BlockSize: 23},
+		"size mismatch": {BlockData: data, BlockSize: int32(len(data)) + 1},
+		"hash mismatch": {BlockData: data, BlockSize: int32(len(data)), ExpectedHash: syncinator.GetBlockHashString([]byte("other data"))},
+	}
+	for name, block := range invalid {
+		if _, err := blockStore.PutBlock(ctx, block); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+	hashes, _ := blockStore.GetBlockHashes(ctx, &emptypb.Empty{})
+	if len(hashes.Hashes) != 0 {
+		t.Fatalf("rejected blocks were stored: %v", hashes.Hashes)
+	}
+
+	if _, err := blockStore.PutBlock(ctx, &syncinator.Block{BlockData: data, BlockSize: int32(len(data)), ExpectedHash: hash}); err != nil {
+		t.Fatalf("put failed: %v", err)
+	}
+	block, err := blockStore.GetBlock(ctx, &syncinator.BlockHash{Hash: hash})
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if block.ExpectedHash != "" {
+		t.Fatalf("expected hash of the upload was stored: %s", block.ExpectedHash)
+	}
+}
+
+func TestBlockStoreRestartKeepsBlocks(t *testing.T) {
+	dir := t.TempDir()
+	addr := "localhost:8082"
+	startBlockStore := func() *exec.Cmd {
+		cmd := exec.Command("_bin/SyncinatorServerExec", "-s", "block", "-p", "8082", "-l", "-dir", dir)
+		cmd.Stderr = os.Stderr
+		cmd.Stdout = os.Stdout
+		if err := cmd.Start(); err != nil {
+			t.Fatalf("could not start BlockStore: %v", err)
+		}
+		time.Sleep(time.Second)
+		return cmd
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cmd := startBlockStore()
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("could not connect to BlockStore: %v", err)
+	}
+	defer conn.Close()
+	client := syncinator.NewBlockStoreClient(conn)
+
+	hashes := make([]string, 0)
+	for i := 0; i < 5; i++ {
+		data := []byte(fmt.Sprintf("block data %d", i))
+		if _, err := client.PutBlock(ctx, &syncinator.Block{BlockData: data, BlockSize: int32(len(data))}); err != nil {
+			cmd.Process.Kill()
+			t.Fatalf("put failed: %v", err)
+		}
+		hashes = append(hashes, syncinator.GetBlockHashString(data))
+	}
+	cmd.Process.Kill()
+	cmd.Wait()
+
+	cmd = startBlockStore()
+	defer cmd.Process.Kill()
+
+	// Wait out the backoff of the connection to the killed process
+	stored, err := client.GetBlockHashes(ctx, &emptypb.Empty{}, grpc.WaitForReady(true))
+	if err != nil {
+		t.Fatalf("could not get block hashes: %v", err)
+	}
+	sort.Strings(hashes)
+	sort.Strings(stored.Hashes)
+	if !SameHashList(hashes, stored.Hashes) {
+		t.Fatalf("expected hashes %v after restart, got %v", hashes, stored.Hashes)
+	}
+	for i, hash := range hashes {
+		block, err := client.GetBlock(ctx, &syncinator.BlockHash{Hash: hash})
+		if err != nil {
+			t.Fatalf("get failed: %v", err)
+		}
+		if syncinator.GetBlockHashString(block.BlockData) != hash {
+			t.Fatalf("block %d changed after restart", i)
+		}
+	}
+}
+
+// Run with -race, e.g. make race-test, to catch unsynchronized access
+func TestBlockStoreConcurrentAccess(t *testing.T) {
+	diskStorage, err := syncinator.NewDiskBlockStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("could not open storage: %v", err)
+	}
+	storages := map[string]syncinator.BlockStorage{
+		"memory": syncinator.NewMemoryBlockStorage(),
+		"disk":   diskStorage,
+	}
+
+	for name, storage := range storages {
+		listener, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			t.Fatalf("could not listen: %v", err)
+		}
+		grpcServer := grpc.NewServer()
+		syncinator.RegisterBlockStoreServer(grpcServer, syncinator.NewBlockStoreWithStorage(storage))
+		go grpcServer.Serve(listener)
+
+		conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+		if err != nil {
+			t.Fatalf("could not connect to BlockStore: %v", err)
+		}
+		client := syncinator.NewBlockStoreClient(conn)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+
+		// Clients upload overlapping sets of blocks, and read them back while others write
+		numClients, numBlocks := 16, 100
+		var wg sync.WaitGroup
+		for c := 0; c < numClients; c++ {
+			wg.Add(1)
+			go func(c int) {
+				defer wg.Done()
+				for i := 0; i < numBlocks; i++ {
+					data := []byte(fmt.Sprintf("block data %d", (c*numBlocks/2+i)%(numClients*numBlocks/2)))
+					hash := syncinator.GetBlockHashString(data)
+					if _, err := client.PutBlock(ctx, &syncinator.Block{BlockData: data, BlockSize: int32(len(data))}); err != nil {
+						t.Errorf("%s: put failed: %v", name, err)
+						return
+					}
+					block, err := client.GetBlock(ctx, &syncinator.BlockHash{Hash: hash})
+					if err != nil || string(block.BlockData) != string(data) {
+						t.Errorf("%s: could not read back block %s: %v", name, hash, err)
+						return
+					}
+					missing, err := client.MissingBlocks(ctx, &syncinator.BlockHashes{Hashes: []string{hash}})
+					if err != nil || len(missing.Hashes) != 0 {
+						t.Errorf("%s: block %s is missing after put: %v", name, hash, err)
+						return
+					}
+					if i%10 == 0 {
+						if _, err := client.GetBlockHashes(ctx, &emptypb.Empty{}); err != nil {
+							t.Errorf("%s: could not get block hashes: %v", name, err)
+							return
+						}
+					}
+				}
+			}(c)
+		}
+		wg.Wait()
+
+		hashes, err := client.GetBlockHashes(ctx, &emptypb.Empty{})
+		cancel()
+		conn.Close()
+		grpcServer.Stop()
+		if err != nil {
+			t.Fatalf("%s: could not get block hashes: %v", name, err)
+		}
+		if len(hashes.Hashes) != numClients*numBlocks/2 {
+			t.Fatalf("%s: expected %d blocks, got %d", name, numClients*numBlocks/2, len(hashes.Hashes))
+		}
+	}
+}
+
+func TestBlockStoreStreams(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	syncinator.RegisterBlockStoreServer(grpcServer, syncinator.NewBlockStore())
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	addr := listener.Addr().String()
+	rpcClient := syncinator.NewSyncinatorRPCClient([]string{}, "", BLOCK_SIZE)
+	defer rpcClient.Close()
+
+	// Far more blocks than fit into a single message
+	blocks := make([]*syncinator.Block, 0)
+	hashes := make([]string, 0)
+	for i := 0; i < 5000; i++ {
+		data := make([]byte, BLOCK_SIZE)
+		copy(data, fmt.Sprintf("block data %d", i))
+		hash := syncinator.GetBlockHashString(data)
+		blocks = append(blocks, &syncinator.Block{BlockData: data, BlockSize: int32(len(data)), ExpectedHash: hash})
+		hashes = append(hashes, hash)
+	}
+	var succ bool
+	if err := rpcClient.PutBlocks(blocks, addr, &succ); err != nil || !succ {
+		t.Fatalf("put blocks failed: %v", err)
+	}
+
+	received := make(map[string]*syncinator.Block)
+	if err := rpcClient.GetBlocks(hashes, addr, &received); err != nil {
+		t.Fatalf("get blocks failed: %v", err)
+	}
+	if len(received) != len(hashes) {
+		t.Fatalf("expected %d blocks, got %d", len(hashes), len(received))
+	}
+	for _, block := range blocks {
+		if string(received[block.ExpectedHash].BlockData) != string(block.BlockData) {
+			t.Fatalf("block %s changed", block.ExpectedHash)
+		}
+	}
+
+	// A missing block fails the whole stream
+	unknown := syncinator.GetBlockHashString([]byte("never uploaded"))
+	err = rpcClient.GetBlocks([]string{hashes[0], unknown, hashes[1]}, addr, &received)
+	if !errors.Is(err, syncinator.ErrBlockNotFound) {
+		t.Fatalf("expected a missing block error, got %v", err)
+	}
+
+	// So does an invalid block, later blocks are not stored
+	data := []byte("another block")
+	invalid := []*syncinator.Block{
+		{BlockData: data, BlockSize: int32(len(data)) + 1},
+		{BlockData: data, BlockSize: int32(len(data))},
+	}
+	if err := rpcClient.PutBlocks(invalid, addr, &succ); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	var missing []string
+	if err := rpcClient.MissingBlocks([]string{syncinator.GetBlockHashString(data)}, addr, &missing); err != nil || len(missing) != 1 {
+		t.Fatalf("block after an invalid one was stored: %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package SyncTest
+
+import (
+	"context"
+	"cse224/proj5/pkg/syncinator"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSQLiteMetaStorageCompareAndSet(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "meta.db")
+	storage, err := syncinator.NewSQLiteMetaStorage(dbPath)
+	if err != nil {
+		t.Fatalf("could not open storage: %v", err)
+	}
+	ctx := context.Background()
+
+	updates := []struct {
+		fileMetaData *syncinator.FileMetaData
+		version      int32
+	}{
+		{&syncinator.FileMetaData{Filename: "testFile1", Version: 2, BlockHashList: []string{"hash1"}}, -1},
+		{&syncinator.FileMetaData{Filename: "testFile1", Version: 1, BlockHashList: []string{"hash1", "hash2"}}, 1},
+		{&syncinator.FileMetaData{Filename: "testFile1", Version: 1, BlockHashList: []string{"hash3"}}, -1},
+		{&syncinator.FileMetaData{Filename: "testFile1", Version: 2, BlockHashList: []string{syncinator.TOMBSTONE_HASHVALUE}}, 2},
+		{&syncinator.FileMetaData{Filename: "testFile2", Version: 1, BlockHashList: []string{}}, 1},
+	}
+	for idx, update := range updates {
+		version, err := storage.CompareAndSet(ctx, update.fileMetaData)
+		if err != nil {
+			t.Fatalf("update %d failed: %v", idx, err)
+		}
+		if version != update.version {
+			t.Fatalf("update %d: expected version %d, got %d", idx, update.version, version)
+		}
+	}
+	storage.Close()
+
+	// Reopening the database finds the accepted updates only
+	storage, err = syncinator.NewSQLiteMetaStorage(dbPath)
+	if err != nil {
+		t.Fatalf("could not reopen storage: %v", err)
+	}
+	defer storage.Close()
+	fileInfoMap, err := storage.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("could not read storage: %v", err)
+	}
+	goldenMeta := map[string]*syncinator.FileMetaData{
+		"testFile1": updates[3].fileMetaData,
+		"testFile2": updates[4].fileMetaData,
+	}
+	if !SameMeta(goldenMeta, fileInfoMap) {
+		t.Fatalf("expected meta %v, got %v", goldenMeta, fileInfoMap)
+	}
+
+	if err := storage.Replace(ctx, map[string]*syncinator.FileMetaData{"testFile3": updates[1].fileMetaData}); err != nil {
+		t.Fatalf("replace failed: %v", err)
+	}
+	fileInfoMap, _ = storage.GetAll(ctx)
+	if len(fileInfoMap) != 1 || fileInfoMap["testFile3"] == nil {
+		t.Fatalf("expected only testFile3 after replace, got %v", fileInfoMap)
+	}
+}
+
+func TestMetaStorageConcurrentUpdates(t *testing.T) {
+	sqliteStorage, err := syncinator.NewSQLiteMetaStorage(filepath.Join(t.TempDir(), "meta.db"))
+	if err != nil {
+		t.Fatalf("could not open storage: %v", err)
+	}
+	defer sqliteStorage.Close()
+
+	storages := map[string]syncinator.MetaStorage{
+		syncinator.META_STORAGE_MEMORY: syncinator.NewMemoryMetaStorage(),
+		syncinator.META_STORAGE_SQLITE: sqliteStorage,
+	}
+	for name, storage := range storages {
+		metaStore := syncinator.NewMetaStoreWithStorage([]string{}, storage)
+
+		// Every client tries to create the same files, only one may win each version
+		var wg sync.WaitGroup
+		var mutex sync.Mutex
+		accepted := make(map[string]int)
+		for client := 0; client < 10; client++ {
+			wg.Add(1)
+			go func(client int) {
+				defer wg.Done()
+				for i := 0; i < 5; i++ {
+					for version := int32(1); version <= 3; version++ {
+						filemeta := &syncinator.FileMetaData{
+							Filename:      fmt.Sprintf("testFile%d", i),
+							Version:       version,
+							BlockHashList: []string{fmt.Sprintf("hash%d-%d", client, version)},
+						}
+						result, err := metaStore.UpdateFile(context.Background(), filemeta)
+						if err != nil {
+							t.Errorf("%s: update failed: %v", name, err)
+							return
+						}
+						if result.Version == version {
+							mutex.Lock()
+							accepted[fmt.Sprintf("%s@%d", filemeta.Filename, version)]++
+							mutex.Unlock()
+						}
+					}
+				}
+			}(client)
+		}
+		wg.Wait()
+
+		for i := 0; i < 5; i++ {
+			for version := 1; version <= 3; version++ {
+				key := fmt.Sprintf("testFile%d@%d", i, version)
+				if accepted[key] != 1 {
+					t.Fatalf("%s: %s was accepted %d times", name, key, accepted[key])
+				}
+			}
+		}
+	}
+}
+
+func TestRaftSQLiteMetaStorage(t *testing.T) {
+	cfgPath := "./config_files/3nodes_sqlite.json"
+	cfg := syncinator.LoadRaftConfigFile(cfgPath)
+	CleanUpDir(cfg.DataDir)
+	defer CleanUpDir(cfg.DataDir)
+
+	test := InitTest(cfgPath)
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		EndTest(test)
+		t.Fatalf("no leader elected")
+	}
+
+	// Enough updates for the servers to snapshot the database
+	goldenMeta := make(map[string]*syncinator.FileMetaData)
+	for i := 1; i <= 5; i++ {
+		filemeta := &syncinator.FileMetaData{
+			Filename:      fmt.Sprintf("testFile%d", i),
+			Version:       1,
+			BlockHashList: []string{fmt.Sprintf("hash%d", i)},
+		}
+		if _, err := test.Clients[leaderIdx].UpdateFile(test.Context, filemeta); err != nil {
+			EndTest(test)
+			t.Fatalf("update failed: %v", err)
+		}
+		goldenMeta[filemeta.Filename] = filemeta
+	}
+	time.Sleep(500 * time.Millisecond)
+	EndTest(test)
+
+	// Each server applied the updates to its own database
+	for idx := range cfg.RaftAddrs {
+		dbPath := filepath.Join(cfg.DataDir, fmt.Sprintf("raft%d", idx), "meta.db")
+		if _, err := os.Stat(dbPath); err != nil {
+			t.Fatalf("server %d has no database: %v", idx, err)
+		}
+		storage, err := syncinator.NewSQLiteMetaStorage(dbPath)
+		if err != nil {
+			t.Fatalf("server %d: could not open database: %v", idx, err)
+		}
+		fileInfoMap, err := storage.GetAll(context.Background())
+		storage.Close()
+		if err != nil || !SameMeta(goldenMeta, fileInfoMap) {
+			t.Fatalf("server %d: expected meta %v, got %v (%v)", idx, goldenMeta, fileInfoMap, err)
+		}
+	}
+
+	// Restart the whole cluster from disk
+	test = InitTest(cfgPath)
+	defer EndTest(test)
+	time.Sleep(500 * time.Millisecond)
+
+	for idx, server := range test.Clients {
+		if _, err := CheckInternalState(nil, nil, nil, goldenMeta, server, test.Context); err != nil {
+			t.Fatalf("server %d: %v", idx, err)
+		}
+	}
+}
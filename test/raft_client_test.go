@@ -1,6 +1,8 @@
 package SyncTest
 
 import (
+	"cse224/proj5/pkg/syncinator"
+	"os"
 	"testing"
 
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
@@ -124,3 +126,56 @@ func TestSyncTwoClientsSameFileLeaderFailure(t *testing.T) {
 	// 	t.Fatalf("wrong file2 contents at client2")
 	// }
 }
+
+// A file whose metadata points at a block that no BlockStore has is not downloaded, and the old copy stays
+func TestSyncMissingBlockLeavesFileUntouched(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+
+	worker1 := InitDirectoryWorker("test0", SRC_PATH)
+	worker2 := InitDirectoryWorker("test1", SRC_PATH)
+	defer worker1.CleanUp()
+	defer worker2.CleanUp()
+
+	file1 := "multi_file1.txt"
+	if err := worker1.AddFile(file1); err != nil {
+		t.FailNow()
+	}
+	if err := SyncClient("localhost:8080", "test0", BLOCK_SIZE, cfgPath); err != nil {
+		t.Fatalf("Sync failed")
+	}
+	if err := SyncClient("localhost:8080", "test1", BLOCK_SIZE, cfgPath); err != nil {
+		t.Fatalf("Sync failed")
+	}
+	before, err := os.ReadFile(worker2.DirectoryName + "/" + file1)
+	if err != nil {
+		t.Fatalf("file1 was not downloaded: %v", err)
+	}
+
+	// The next version references a block that was never uploaded
+	filemeta := &syncinator.FileMetaData{
+		Filename:      file1,
+		Version:       2,
+		BlockHashList: []string{syncinator.GetBlockHashString([]byte("never uploaded"))},
+	}
+	if _, err := test.Clients[leaderIdx].UpdateFile(test.Context, filemeta); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	if err := SyncClient("localhost:8080", "test1", BLOCK_SIZE, cfgPath); err == nil {
+		t.Fatalf("sync with a missing block succeeded")
+	}
+	after, err := os.ReadFile(worker2.DirectoryName + "/" + file1)
+	if err != nil || string(after) != string(before) {
+		t.Fatalf("file1 changed after a failed download: %v", err)
+	}
+	fileMetaMap, err := LoadMetaFromDB(worker2.DirectoryName)
+	if err != nil || fileMetaMap[file1] == nil || fileMetaMap[file1].Version != 1 {
+		t.Fatalf("expected version 1 of file1 in the index, got %v (%v)", fileMetaMap[file1], err)
+	}
+}
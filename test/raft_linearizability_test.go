@@ -0,0 +1,148 @@
+package SyncTest
+
+import (
+	"cse224/proj5/pkg/syncinator"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestLinearizabilityChecker(t *testing.T) {
+	update := &syncinator.FileMetaData{Filename: "testFile1", Version: 1, BlockHashList: []string{"hash1"}}
+	updated := map[string]*syncinator.FileMetaData{"testFile1": update}
+	empty := map[string]*syncinator.FileMetaData{}
+
+	// A read that starts after the update completes must see it
+	staleRead := []syncinator.HistoryEvent{
+		{Kind: syncinator.HistoryInvoke, OpId: 0, ClientId: 1, Update: update},
+		{Kind: syncinator.HistoryComplete, OpId: 0, ClientId: 1, Version: 1},
+		{Kind: syncinator.HistoryInvoke, OpId: 1, ClientId: 2},
+		{Kind: syncinator.HistoryComplete, OpId: 1, ClientId: 2, FileInfoMap: empty},
+	}
+	if err := syncinator.CheckLinearizable(staleRead); !errors.Is(err, syncinator.ErrNotLinearizable) {
+		t.Fatalf("stale read was accepted: %v", err)
+	}
+
+	// Concurrent reads may see the update or not, but not go back
+	concurrentReads := []syncinator.HistoryEvent{
+		{Kind: syncinator.HistoryInvoke, OpId: 0, ClientId: 1, Update: update},
+		{Kind: syncinator.HistoryInvoke, OpId: 1, ClientId: 2},
+		{Kind: syncinator.HistoryComplete, OpId: 1, ClientId: 2, FileInfoMap: updated},
+		{Kind: syncinator.HistoryInvoke, OpId: 2, ClientId: 3},
+		{Kind: syncinator.HistoryComplete, OpId: 2, ClientId: 3, FileInfoMap: updated},
+		{Kind: syncinator.HistoryComplete, OpId: 0, ClientId: 1, Version: 1},
+	}
+	if err := syncinator.CheckLinearizable(concurrentReads); err != nil {
+		t.Fatalf("concurrent reads were rejected: %v", err)
+	}
+	concurrentReads[4].FileInfoMap = empty
+	if err := syncinator.CheckLinearizable(concurrentReads); !errors.Is(err, syncinator.ErrNotLinearizable) {
+		t.Fatalf("read going back was accepted: %v", err)
+	}
+
+	// A failed update may have taken effect
+	failedUpdate := []syncinator.HistoryEvent{
+		{Kind: syncinator.HistoryInvoke, OpId: 0, ClientId: 1, Update: update},
+		{Kind: syncinator.HistoryComplete, OpId: 0, ClientId: 1, Err: syncinator.ErrCommitUnknown},
+		{Kind: syncinator.HistoryInvoke, OpId: 1, ClientId: 2},
+		{Kind: syncinator.HistoryComplete, OpId: 1, ClientId: 2, FileInfoMap: updated},
+		{Kind: syncinator.HistoryInvoke, OpId: 2, ClientId: 2, Update: update},
+		{Kind: syncinator.HistoryComplete, OpId: 2, ClientId: 2, Version: -1},
+	}
+	if err := syncinator.CheckLinearizable(failedUpdate); err != nil {
+		t.Fatalf("failed update that took effect was rejected: %v", err)
+	}
+}
+
+func TestRaftLinearizableUnderFaults(t *testing.T) {
+	cfgPath := "./config_files/5nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	seed := time.Now().UnixNano()
+	t.Logf("seed %d", seed)
+	random := rand.New(rand.NewSource(seed))
+
+	history := syncinator.NewHistory()
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for clientIdx := 0; clientIdx < 4; clientIdx++ {
+		rpcClient := syncinator.NewSyncinatorRPCClient(test.Ips, "", BLOCK_SIZE)
+		client := syncinator.NewHistoryClient(&rpcClient, history)
+		clientRandom := rand.New(rand.NewSource(random.Int63()))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer rpcClient.Close()
+			versions := make(map[string]int32)
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if clientRandom.Intn(2) == 0 {
+					fileInfoMap := make(map[string]*syncinator.FileMetaData)
+					if client.GetFileInfoMap(&fileInfoMap) == nil {
+						for filename, fileMetaData := range fileInfoMap {
+							versions[filename] = fileMetaData.Version
+						}
+					}
+					continue
+				}
+				filename := fmt.Sprintf("testFile%d", clientRandom.Intn(3))
+				update := &syncinator.FileMetaData{
+					Filename:      filename,
+					Version:       versions[filename] + 1,
+					BlockHashList: []string{fmt.Sprintf("hash%d-%d", rpcClient.ClientId, i)},
+				}
+				var latestVersion int32
+				if client.UpdateFile(update, &latestVersion) == nil && latestVersion > 0 {
+					versions[filename] = latestVersion
+				}
+			}
+		}()
+	}
+
+	// Crash, restore, isolate and heal servers at random, keeping at most
+	// two crashed so that the cluster can make progress in between
+	crashed := make(map[int]bool)
+	for round := 0; round < 30; round++ {
+		idx := random.Intn(len(test.Clients))
+		switch random.Intn(4) {
+		case 0:
+			if len(crashed) < 2 && !crashed[idx] {
+				test.Clients[idx].Crash(test.Context, &emptypb.Empty{})
+				crashed[idx] = true
+			}
+		case 1:
+			for crashedIdx := range crashed {
+				test.Clients[crashedIdx].Restore(test.Context, &emptypb.Empty{})
+				delete(crashed, crashedIdx)
+			}
+		case 2:
+			IsolateServer(test, idx)
+		case 3:
+			HealPartitions(test)
+		}
+		time.Sleep(time.Duration(100+random.Intn(400)) * time.Millisecond)
+	}
+
+	close(stop)
+	wg.Wait()
+	for crashedIdx := range crashed {
+		test.Clients[crashedIdx].Restore(test.Context, &emptypb.Empty{})
+	}
+	HealPartitions(test)
+
+	events := history.Events()
+	t.Logf("checking %d events", len(events))
+	if err := syncinator.CheckLinearizable(events); err != nil {
+		t.Fatalf("%v (seed %d)", err, seed)
+	}
+}
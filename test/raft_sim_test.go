@@ -0,0 +1,189 @@
+package SyncTest
+
+import (
+	context "context"
+	"cse224/proj5/pkg/syncinator"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+var simFaults = syncinator.SimFaults{
+	DropRate:    0.05,
+	MaxDelay:    20 * time.Millisecond,
+	ReorderRate: 0.1,
+}
+
+func TestRaftSimLeaderElection(t *testing.T) {
+	cluster, err := syncinator.NewSimCluster(5, 1, simFaults)
+	if err != nil {
+		t.Fatalf("could not start the cluster: %v", err)
+	}
+
+	isElected := func() bool {
+		leaderId, _ := cluster.Leader()
+		return leaderId != syncinator.NO_LEADER
+	}
+	if !cluster.RunUntil(5*time.Second, isElected) {
+		t.Fatalf("no leader elected")
+	}
+	leaderId, leaderTerm := cluster.Leader()
+
+	// The remaining majority elects a new leader
+	cluster.Network.Partition([]int64{leaderId})
+	isReelected := func() bool {
+		newLeaderId, newLeaderTerm := cluster.Leader()
+		return newLeaderId != leaderId && newLeaderTerm > leaderTerm
+	}
+	if !cluster.RunUntil(5*time.Second, isReelected) {
+		t.Fatalf("no new leader elected after isolating leader %d", leaderId)
+	}
+}
+
+// The seed draws the timeouts, the faults and the partition schedule, so two
+// runs send the same messages at the same instants and end with the same logs
+func TestRaftSimSameSeedSameRun(t *testing.T) {
+	// Goroutines woken by the same timer run in a stable order on one thread
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	var traces [2][]string
+	var logs [2][][]*syncinator.UpdateOperation
+	for run := range traces {
+		traces[run], logs[run] = runSimWorkload(7)
+	}
+
+	if len(traces[0]) == 0 {
+		t.Fatalf("no messages were sent")
+	}
+	for i := 0; i < len(traces[0]) || i < len(traces[1]); i++ {
+		if i >= len(traces[0]) || i >= len(traces[1]) || traces[0][i] != traces[1][i] {
+			t.Fatalf("runs with the same seed diverge at message %d of %d and %d", i, len(traces[0]), len(traces[1]))
+		}
+	}
+	for id := range logs[0] {
+		if !SameLog(logs[0][id], logs[1][id]) {
+			t.Fatalf("runs with the same seed end with different logs on server %d", id)
+		}
+	}
+}
+
+// Sends updates to a cluster under faults and a schedule of partitions.
+// Returns the messages sent and the final log of each server.
+func runSimWorkload(seed int64) ([]string, [][]*syncinator.UpdateOperation) {
+	cluster, _ := syncinator.NewSimCluster(5, seed, simFaults)
+	cluster.SchedulePartitions(time.Second, 8*time.Second)
+
+	for i := 0; i < 10; i++ {
+		leaderId, _ := cluster.Leader()
+		if leaderId == syncinator.NO_LEADER {
+			cluster.RunFor(500 * time.Millisecond)
+			continue
+		}
+		filemeta := &syncinator.FileMetaData{
+			Filename:      fmt.Sprintf("testFile%d", i),
+			Version:       1,
+			BlockHashList: []string{fmt.Sprintf("hash%d", i)},
+		}
+		cluster.RunWhile(2*time.Second, func() {
+			cluster.Servers[leaderId].UpdateFile(context.Background(), filemeta)
+		})
+	}
+	cluster.RunFor(2 * time.Second)
+
+	logs := make([][]*syncinator.UpdateOperation, len(cluster.Servers))
+	for id, server := range cluster.Servers {
+		state, _ := server.GetInternalState(context.Background(), &emptypb.Empty{})
+		logs[id] = state.Log
+	}
+	return cluster.Network.Trace(), logs
+}
+
+func TestRaftSimLogsConvergeAfterPartitions(t *testing.T) {
+	cluster, err := syncinator.NewSimCluster(5, 42, simFaults)
+	if err != nil {
+		t.Fatalf("could not start the cluster: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		if i%3 == 0 {
+			cluster.PartitionRandomly()
+		} else if i%3 == 2 {
+			cluster.Network.Heal()
+		}
+
+		// Updates sent to a server without a majority may fail
+		leaderId, _ := cluster.Leader()
+		if leaderId == syncinator.NO_LEADER {
+			cluster.RunFor(time.Second)
+			continue
+		}
+		filemeta := &syncinator.FileMetaData{
+			Filename:      fmt.Sprintf("testFile%d", i),
+			Version:       1,
+			BlockHashList: []string{fmt.Sprintf("hash%d", i)},
+		}
+		cluster.RunWhile(5*time.Second, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			cluster.Servers[leaderId].UpdateFile(ctx, filemeta)
+		})
+	}
+
+	cluster.Network.Heal()
+	cluster.Network.SetFaults(syncinator.SimFaults{})
+	cluster.RunFor(3 * time.Second)
+
+	leaderId, _ := cluster.Leader()
+	if leaderId == syncinator.NO_LEADER {
+		t.Fatalf("no leader after healing the partitions")
+	}
+	leaderState, _ := cluster.Servers[leaderId].GetInternalState(context.Background(), &emptypb.Empty{})
+	for id, server := range cluster.Servers {
+		state, _ := server.GetInternalState(context.Background(), &emptypb.Empty{})
+		if !SameLog(state.Log, leaderState.Log) {
+			t.Fatalf("server %d has a different log from leader %d", id, leaderId)
+		}
+		if state.CommitIndex != leaderState.CommitIndex {
+			t.Fatalf("server %d has commit index %d, leader %d has %d", id, state.CommitIndex, leaderId, leaderState.CommitIndex)
+		}
+	}
+}
+
+// A restored server must be able to campaign again, so its link faults go
+func TestRaftSimRestoreClearsLinkFaults(t *testing.T) {
+	cluster, err := syncinator.NewSimCluster(3, 1, syncinator.SimFaults{})
+	if err != nil {
+		t.Fatalf("could not start the cluster: %v", err)
+	}
+	isElected := func() bool {
+		leaderId, _ := cluster.Leader()
+		return leaderId != syncinator.NO_LEADER
+	}
+	if !cluster.RunUntil(5*time.Second, isElected) {
+		t.Fatalf("no leader elected")
+	}
+	leaderId, _ := cluster.Leader()
+	targetId := (leaderId + 1) % int64(len(cluster.Servers))
+
+	cutOff := &syncinator.LinkFaults{}
+	for id := range cluster.Servers {
+		cutOff.Links = append(cutOff.Links, &syncinator.LinkFault{ServerId: int64(id), IsPartitioned: true})
+	}
+	cluster.Servers[targetId].SetLinkFaults(context.Background(), cutOff)
+	cluster.Servers[targetId].Crash(context.Background(), &emptypb.Empty{})
+	cluster.Restore(targetId)
+
+	var transferErr error
+	cluster.RunWhile(5*time.Second, func() {
+		_, transferErr = cluster.Servers[leaderId].TransferLeadership(context.Background(), &syncinator.TransferLeadershipInput{TargetId: targetId})
+	})
+	if transferErr != nil {
+		t.Fatalf("could not transfer leadership to restored server %d: %v", targetId, transferErr)
+	}
+	if newLeaderId, _ := cluster.Leader(); newLeaderId != targetId {
+		t.Fatalf("leader is %d, expected restored server %d", newLeaderId, targetId)
+	}
+}
@@ -1,14 +1,988 @@
 package SyncTest
 
 import (
+	context "context"
 	"cse224/proj5/pkg/syncinator"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
+func TestRaftLeaderElection(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	// A leader is elected without calling SetLeader
+	leaderIdx, leaderTerm := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+
+	test.Clients[leaderIdx].Crash(test.Context, &emptypb.Empty{})
+	time.Sleep(2 * time.Second)
+
+	// The remaining majority elects a new leader
+	newLeaderIdx, newLeaderTerm := GetLeader(test)
+	if newLeaderIdx == -1 || newLeaderIdx == leaderIdx {
+		t.Fatalf("no new leader elected after crashing server %d", leaderIdx)
+	}
+	if newLeaderTerm <= leaderTerm {
+		t.Fatalf("expected new term greater than %d, got %d", leaderTerm, newLeaderTerm)
+	}
+
+	// The old leader rejoins as a follower of the new term
+	test.Clients[leaderIdx].Restore(test.Context, &emptypb.Empty{})
+	time.Sleep(time.Second)
+
+	isLeader := false
+	if _, err := CheckInternalState(&isLeader, nil, nil, nil, test.Clients[leaderIdx], test.Context); err != nil {
+		t.Fatalf("restored server: %v", err)
+	}
+	isLeader = true
+	if _, err := CheckInternalState(&isLeader, nil, nil, nil, test.Clients[newLeaderIdx], test.Context); err != nil {
+		t.Fatalf("new leader: %v", err)
+	}
+}
+
+func TestRaftBackgroundReplication(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+
+	filemeta1 := &syncinator.FileMetaData{
+		Filename:      "testFile1",
+		Version:       1,
+		BlockHashList: nil,
+	}
+	version, err := test.Clients[leaderIdx].UpdateFile(test.Context, filemeta1)
+	if err != nil || version.Version != 1 {
+		t.Fatalf("update failed: %v %v", version, err)
+	}
+
+	// Followers learn about the commit from heartbeats alone
+	time.Sleep(500 * time.Millisecond)
+
+	goldenMeta := map[string]*syncinator.FileMetaData{"testFile1": filemeta1}
+	for idx, server := range test.Clients {
+		if _, err := CheckInternalState(nil, nil, nil, goldenMeta, server, test.Context); err != nil {
+			t.Fatalf("server %d: %v", idx, err)
+		}
+	}
+}
+
+func TestRaftRestartRecoversState(t *testing.T) {
+	cfgPath := "./config_files/3nodes_persistent.json"
+	CleanUpDir("raft_data")
+	defer CleanUpDir("raft_data")
+
+	test := InitTest(cfgPath)
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		EndTest(test)
+		t.Fatalf("no leader elected")
+	}
+
+	filemeta1 := &syncinator.FileMetaData{
+		Filename:      "testFile1",
+		Version:       1,
+		BlockHashList: []string{"hash1"},
+	}
+	filemeta2 := &syncinator.FileMetaData{
+		Filename:      "testFile1",
+		Version:       2,
+		BlockHashList: []string{"hash2"},
+	}
+	for _, filemeta := range []*syncinator.FileMetaData{filemeta1, filemeta2} {
+		if _, err := test.Clients[leaderIdx].UpdateFile(test.Context, filemeta); err != nil {
+			EndTest(test)
+			t.Fatalf("update failed: %v", err)
+		}
+	}
+	EndTest(test)
+
+	// Restart the whole cluster from disk
+	test = InitTest(cfgPath)
+	defer EndTest(test)
+	time.Sleep(500 * time.Millisecond)
+
+	goldenMeta := map[string]*syncinator.FileMetaData{"testFile1": filemeta2}
+	for idx, server := range test.Clients {
+		if _, err := CheckInternalState(nil, nil, nil, goldenMeta, server, test.Context); err != nil {
+			t.Fatalf("server %d: %v", idx, err)
+		}
+	}
+}
+
+func TestRaftSnapshotCatchUp(t *testing.T) {
+	cfgPath := "./config_files/3nodes_snapshot.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+	followerIdx := (leaderIdx + 1) % len(test.Clients)
+	test.Clients[followerIdx].Crash(test.Context, &emptypb.Empty{})
+
+	// Enough updates for the leader to compact the entries the follower missed
+	goldenMeta := make(map[string]*syncinator.FileMetaData)
+	for i := 1; i <= 10; i++ {
+		filemeta := &syncinator.FileMetaData{
+			Filename:      fmt.Sprintf("testFile%d", i),
+			Version:       1,
+			BlockHashList: []string{fmt.Sprintf("hash%d", i)},
+		}
+		if _, err := test.Clients[leaderIdx].UpdateFile(test.Context, filemeta); err != nil {
+			t.Fatalf("update failed: %v", err)
+		}
+		goldenMeta[filemeta.Filename] = filemeta
+	}
+
+	state, err := test.Clients[leaderIdx].GetInternalState(test.Context, &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("could not get leader state: %v", err)
+	}
+	if len(state.Log) >= 10 {
+		t.Fatalf("expected leader log to be compacted, got %d entries", len(state.Log))
+	}
+
+	// The follower catches up from the snapshot
+	test.Clients[followerIdx].Restore(test.Context, &emptypb.Empty{})
+	time.Sleep(time.Second)
+
+	if _, err := CheckInternalState(nil, nil, nil, goldenMeta, test.Clients[followerIdx], test.Context); err != nil {
+		t.Fatalf("follower: %v", err)
+	}
+}
+
+func TestRaftAddAndRemoveServer(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer func() { EndTest(test) }()
+
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+
+	// A new server joins and receives the existing state
+	newServer := JoinRaftServer(&test, 3, "localhost:9010")
+	if _, err := test.Clients[leaderIdx].AddServer(test.Context, &syncinator.RaftMember{Id: 3, Addr: "localhost:9010"}); err != nil {
+		t.Fatalf("add server failed: %v", err)
+	}
+
+	filemeta1 := &syncinator.FileMetaData{
+		Filename:      "testFile1",
+		Version:       1,
+		BlockHashList: []string{"hash1"},
+	}
+	if _, err := test.Clients[leaderIdx].UpdateFile(test.Context, filemeta1); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	goldenMeta := map[string]*syncinator.FileMetaData{filemeta1.Filename: filemeta1}
+	if _, err := CheckInternalState(nil, nil, nil, goldenMeta, newServer, test.Context); err != nil {
+		t.Fatalf("new server: %v", err)
+	}
+
+	// Once a follower is removed, the new server is needed for a majority
+	removedIdx := (leaderIdx + 1) % 3
+	remainingIdx := (leaderIdx + 2) % 3
+	if _, err := test.Clients[leaderIdx].RemoveServer(test.Context, &syncinator.RaftMember{Id: int64(removedIdx)}); err != nil {
+		t.Fatalf("remove server failed: %v", err)
+	}
+	test.Clients[remainingIdx].Crash(test.Context, &emptypb.Empty{})
+
+	filemeta2 := &syncinator.FileMetaData{
+		Filename:      "testFile2",
+		Version:       1,
+		BlockHashList: []string{"hash2"},
+	}
+	if _, err := test.Clients[leaderIdx].UpdateFile(test.Context, filemeta2); err != nil {
+		t.Fatalf("update after removal failed: %v", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	goldenMeta[filemeta2.Filename] = filemeta2
+	if _, err := CheckInternalState(nil, nil, nil, goldenMeta, newServer, test.Context); err != nil {
+		t.Fatalf("new server after removal: %v", err)
+	}
+
+	// The removed server is added back and catches up on what it missed
+	if _, err := test.Clients[leaderIdx].AddServer(test.Context, &syncinator.RaftMember{Id: int64(removedIdx), Addr: test.Ips[removedIdx]}); err != nil {
+		t.Fatalf("re-adding server failed: %v", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	if _, err := CheckInternalState(nil, nil, nil, goldenMeta, test.Clients[removedIdx], test.Context); err != nil {
+		t.Fatalf("re-added server: %v", err)
+	}
+}
+
+func TestRaftReadFailsWithoutMajority(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+	if _, err := test.Clients[leaderIdx].GetFileInfoMap(test.Context, &emptypb.Empty{}); err != nil {
+		t.Fatalf("read with majority failed: %v", err)
+	}
+
+	for idx := range test.Clients {
+		if idx != leaderIdx {
+			test.Clients[idx].Crash(test.Context, &emptypb.Empty{})
+		}
+	}
+
+	// The read is rejected instead of blocking until a majority returns
+	start := time.Now()
+	if _, err := test.Clients[leaderIdx].GetFileInfoMap(test.Context, &emptypb.Empty{}); err == nil {
+		t.Fatalf("expected read without majority to fail")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("read without majority took %v", elapsed)
+	}
+}
+
+func TestRaftLeaseReads(t *testing.T) {
+	cfgPath := "./config_files/3nodes_lease.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+
+	filemeta1 := &syncinator.FileMetaData{
+		Filename:      "testFile1",
+		Version:       1,
+		BlockHashList: []string{"hash1"},
+	}
+	if _, err := test.Clients[leaderIdx].UpdateFile(test.Context, filemeta1); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	// Concurrent reads observe the committed update
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fileInfoMap, err := test.Clients[leaderIdx].GetFileInfoMap(test.Context, &emptypb.Empty{})
+			if err != nil {
+				errs <- err
+			} else if fileInfoMap.FileInfoMap[filemeta1.Filename].GetVersion() != 1 {
+				errs <- fmt.Errorf("read missed the update: %v", fileInfoMap)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("read failed: %v", err)
+	}
+
+	// A follower that hears from the leader rejects other candidates
+	followerIdx := (leaderIdx + 1) % len(test.Clients)
+	vote, err := test.Clients[followerIdx].RequestVote(test.Context, &syncinator.RequestVoteInput{
+		Term:         100,
+		CandidateId:  int64((leaderIdx + 2) % len(test.Clients)),
+		LastLogIndex: 100,
+		LastLogTerm:  100,
+	})
+	if err != nil {
+		t.Fatalf("request vote failed: %v", err)
+	}
+	if vote.VoteGranted {
+		t.Fatalf("follower voted while the leader is alive")
+	}
+}
+
+func TestRaftReplicaReads(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+	followerIdx := (leaderIdx + 1) % len(test.Clients)
+
+	filemeta1 := &syncinator.FileMetaData{
+		Filename:      "testFile1",
+		Version:       1,
+		BlockHashList: []string{"hash1"},
+	}
+	if _, err := test.Clients[leaderIdx].UpdateFile(test.Context, filemeta1); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	leaderRead, err := test.Clients[leaderIdx].GetReplicaFileInfoMap(test.Context, &syncinator.ReplicaReadInput{MinIndex: -1})
+	if err != nil {
+		t.Fatalf("leader replica read failed: %v", err)
+	}
+
+	// A follower waits until it has applied the requested index
+	followerRead, err := test.Clients[followerIdx].GetReplicaFileInfoMap(test.Context, &syncinator.ReplicaReadInput{MinIndex: leaderRead.LastApplied})
+	if err != nil {
+		t.Fatalf("follower replica read failed: %v", err)
+	}
+	if followerRead.LastApplied < leaderRead.LastApplied || followerRead.LastAppliedTerm != leaderRead.LastAppliedTerm {
+		t.Fatalf("expected follower at index %d, got %d", leaderRead.LastApplied, followerRead.LastApplied)
+	}
+	goldenMeta := map[string]*syncinator.FileMetaData{filemeta1.Filename: filemeta1}
+	if !SameMeta(goldenMeta, followerRead.FileInfoMap.FileInfoMap) {
+		t.Fatalf("expected meta %v, got %v", goldenMeta, followerRead.FileInfoMap)
+	}
+
+	// An index that is never applied is rejected
+	if _, err := test.Clients[followerIdx].GetReplicaFileInfoMap(test.Context, &syncinator.ReplicaReadInput{MinIndex: 100}); err == nil {
+		t.Fatalf("expected read at unapplied index to fail")
+	}
+
+	// A follower that lost contact with every leader stops serving
+	for idx := range test.Clients {
+		if idx != followerIdx {
+			test.Clients[idx].Crash(test.Context, &emptypb.Empty{})
+		}
+	}
+	time.Sleep(time.Second)
+	if _, err := test.Clients[followerIdx].GetReplicaFileInfoMap(test.Context, &syncinator.ReplicaReadInput{MinIndex: -1}); err == nil {
+		t.Fatalf("expected isolated follower to reject replica reads")
+	}
+}
+
+func TestRaftNotLeaderHint(t *testing.T) {
+	cfgPath := "./config_files/5nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+	followerIdx := (leaderIdx + 1) % len(test.Clients)
+
+	// A follower rejects the request and names the leader
+	_, err := test.Clients[followerIdx].GetFileInfoMap(test.Context, &emptypb.Empty{})
+	leaderHint := syncinator.LeaderHintFromError(err)
+	if leaderHint == nil {
+		t.Fatalf("expected leader hint, got %v", err)
+	}
+	if leaderHint.LeaderId != int64(leaderIdx) || leaderHint.LeaderAddr != test.Ips[leaderIdx] {
+		t.Fatalf("expected leader %d at %s, got %v", leaderIdx, test.Ips[leaderIdx], leaderHint)
+	}
+
+	// A client starting at the follower is redirected to the leader
+	rpcClient := syncinator.NewSyncinatorRPCClient([]string{test.Ips[followerIdx]}, "", BLOCK_SIZE)
+	defer rpcClient.Close()
+	var latestVersion int32
+	filemeta1 := &syncinator.FileMetaData{
+		Filename:      "testFile1",
+		Version:       1,
+		BlockHashList: []string{"hash1"},
+	}
+	if err := rpcClient.UpdateFile(filemeta1, &latestVersion); err != nil || latestVersion != 1 {
+		t.Fatalf("update through redirect failed: %d %v", latestVersion, err)
+	}
+}
+
+// The client returns the leader's own errors instead of looking further
+func TestRaftClientGetsLeaderError(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	if leaderIdx, _ := GetLeader(test); leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+
+	// Two clients sharing a session, the second one falls behind
+	rpcClient := syncinator.NewSyncinatorRPCClient(test.Ips, "", BLOCK_SIZE)
+	defer rpcClient.Close()
+	staleClient := syncinator.NewSyncinatorRPCClient(test.Ips, "", BLOCK_SIZE)
+	defer staleClient.Close()
+	staleClient.ClientId = rpcClient.ClientId
+
+	var latestVersion int32
+	for version := int32(1); version <= 2; version++ {
+		filemeta := &syncinator.FileMetaData{
+			Filename:      "testFile1",
+			Version:       version,
+			BlockHashList: []string{fmt.Sprintf("hash%d", version)},
+		}
+		if err := rpcClient.UpdateFile(filemeta, &latestVersion); err != nil {
+			t.Fatalf("update %d failed: %v", version, err)
+		}
+	}
+
+	filemeta := &syncinator.FileMetaData{
+		Filename:      "testFile2",
+		Version:       1,
+		BlockHashList: []string{"hash3"},
+	}
+	err := staleClient.UpdateFile(filemeta, &latestVersion)
+	if status.Convert(err).Message() != syncinator.ErrStaleRequest.Error() {
+		t.Fatalf("expected %v, got %v", syncinator.ErrStaleRequest, err)
+	}
+}
+
+func TestRaftUpdateFileDeduplicated(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+
+	filemeta1 := &syncinator.FileMetaData{
+		Filename:      "testFile1",
+		Version:       1,
+		BlockHashList: []string{"hash1"},
+		ClientId:      42,
+		Sequence:      1,
+	}
+	for i := 0; i < 2; i++ {
+		version, err := test.Clients[leaderIdx].UpdateFile(test.Context, filemeta1)
+		if err != nil || version.Version != 1 {
+			t.Fatalf("attempt %d: expected version 1, got %v %v", i, version, err)
+		}
+	}
+
+	// A retry after failover gets the same response from the new leader
+	test.Clients[leaderIdx].Crash(test.Context, &emptypb.Empty{})
+	time.Sleep(2 * time.Second)
+	newLeaderIdx, _ := GetLeader(test)
+	if newLeaderIdx == -1 || newLeaderIdx == leaderIdx {
+		t.Fatalf("no new leader elected")
+	}
+	version, err := test.Clients[newLeaderIdx].UpdateFile(test.Context, filemeta1)
+	if err != nil || version.Version != 1 {
+		t.Fatalf("retry after failover: expected version 1, got %v %v", version, err)
+	}
+
+	goldenMeta := map[string]*syncinator.FileMetaData{filemeta1.Filename: filemeta1}
+	if _, err := CheckInternalState(nil, nil, nil, goldenMeta, test.Clients[newLeaderIdx], test.Context); err != nil {
+		t.Fatalf("new leader: %v", err)
+	}
+}
+
+func TestRaftTransferLeadership(t *testing.T) {
+	// Followers under leases ignore candidates other than the transfer target
+	cfgPath := "./config_files/3nodes_lease.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, leaderTerm := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+	targetIdx := (leaderIdx + 1) % len(test.Clients)
+
+	if _, err := test.Clients[leaderIdx].TransferLeadership(test.Context, &syncinator.TransferLeadershipInput{TargetId: int64(targetIdx)}); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	newLeaderIdx, newLeaderTerm := GetLeader(test)
+	if newLeaderIdx != targetIdx || newLeaderTerm <= leaderTerm {
+		t.Fatalf("expected server %d to lead a term after %d, got server %d in term %d", targetIdx, leaderTerm, newLeaderIdx, newLeaderTerm)
+	}
+
+	filemeta1 := &syncinator.FileMetaData{
+		Filename:      "testFile1",
+		Version:       1,
+		BlockHashList: []string{"hash1"},
+	}
+	if _, err := test.Clients[targetIdx].UpdateFile(test.Context, filemeta1); err != nil {
+		t.Fatalf("update on new leader failed: %v", err)
+	}
+}
+
+func TestRaftPartitionedServerRejoins(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, leaderTerm := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+	isolatedIdx := (leaderIdx + 1) % len(test.Clients)
+
+	// The isolated server times out repeatedly, but never wins a pre-vote
+	IsolateServer(test, isolatedIdx)
+	time.Sleep(2500 * time.Millisecond)
+	HealPartitions(test)
+	time.Sleep(300 * time.Millisecond)
+
+	newLeaderIdx, newLeaderTerm := GetLeader(test)
+	if newLeaderIdx != leaderIdx || newLeaderTerm != leaderTerm {
+		t.Fatalf("expected server %d to still lead term %d, got server %d in term %d", leaderIdx, leaderTerm, newLeaderIdx, newLeaderTerm)
+	}
+	for idx, server := range test.Clients {
+		state, err := server.GetInternalState(test.Context, &emptypb.Empty{})
+		if err != nil {
+			t.Fatalf("could not get state of server %d: %v", idx, err)
+		}
+		if state.Term != leaderTerm {
+			t.Fatalf("expected server %d to be in term %d, got %d", idx, leaderTerm, state.Term)
+		}
+	}
+}
+
+func TestRaftLeaderStepsDownWithoutQuorum(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, leaderTerm := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+
+	// The others elect a new leader, while the isolated one steps down by itself
+	IsolateServer(test, leaderIdx)
+	time.Sleep(2500 * time.Millisecond)
+
+	state, err := test.Clients[leaderIdx].GetInternalState(test.Context, &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("could not get state of server %d: %v", leaderIdx, err)
+	}
+	if state.Status != syncinator.ServerStatus_FOLLOWER || state.Term != leaderTerm {
+		t.Fatalf("expected isolated leader to be a follower in term %d, got %v in term %d", leaderTerm, state.Status, state.Term)
+	}
+	newLeaderIdx, newLeaderTerm := GetLeader(test)
+	if newLeaderIdx == -1 || newLeaderIdx == leaderIdx || newLeaderTerm <= leaderTerm {
+		t.Fatalf("expected a new leader after term %d, got server %d in term %d", leaderTerm, newLeaderIdx, newLeaderTerm)
+	}
+
+	HealPartitions(test)
+	time.Sleep(300 * time.Millisecond)
+	if idx, term := GetLeader(test); idx != newLeaderIdx || term != newLeaderTerm {
+		t.Fatalf("expected server %d to lead term %d after healing, got server %d in term %d", newLeaderIdx, newLeaderTerm, idx, term)
+	}
+}
+
+func TestRaftFollowerCatchesUpLongLog(t *testing.T) {
+	cfgPath := "./config_files/3nodes_long_log.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+	followerIdx := (leaderIdx + 1) % len(test.Clients)
+	test.Clients[followerIdx].Crash(test.Context, &emptypb.Empty{})
+	UpdateFiles(test, leaderIdx, 2000)
+
+	// Thousands of entries arrive in a few batches, not one round trip each
+	test.Clients[followerIdx].Restore(test.Context, &emptypb.Empty{})
+	time.Sleep(500 * time.Millisecond)
+
+	leaderState, err := test.Clients[leaderIdx].GetInternalState(test.Context, &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("could not get leader state: %v", err)
+	}
+	if len(leaderState.Log) < 2000 {
+		t.Fatalf("expected at least 2000 entries on the leader, got %d", len(leaderState.Log))
+	}
+	if _, err := CheckInternalState(nil, nil, leaderState.Log, leaderState.MetaMap.FileInfoMap, test.Clients[followerIdx], test.Context); err != nil {
+		t.Fatalf("follower: %v", err)
+	}
+}
+
+func TestRaftConflictingEntriesReplaced(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+
+	// The isolated leader appends entries that never commit
+	IsolateServer(test, leaderIdx)
+	UpdateFiles(test, leaderIdx, 50)
+	time.Sleep(2500 * time.Millisecond)
+
+	newLeaderIdx, _ := GetLeader(test)
+	if newLeaderIdx == -1 || newLeaderIdx == leaderIdx {
+		t.Fatalf("no new leader elected")
+	}
+	UpdateFiles(test, newLeaderIdx, 10)
+
+	HealPartitions(test)
+	time.Sleep(500 * time.Millisecond)
+
+	newLeaderState, err := test.Clients[newLeaderIdx].GetInternalState(test.Context, &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("could not get leader state: %v", err)
+	}
+	if _, err := CheckInternalState(nil, nil, newLeaderState.Log, newLeaderState.MetaMap.FileInfoMap, test.Clients[leaderIdx], test.Context); err != nil {
+		t.Fatalf("old leader: %v", err)
+	}
+}
+
+func TestRaftLearner(t *testing.T) {
+	// Servers 0 to 2 vote, server 3 is a learner
+	cfgPath := "./config_files/4nodes_learner.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+	learnerIdx := 3
+
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 || leaderIdx == learnerIdx {
+		t.Fatalf("expected a voter to lead, got server %d", leaderIdx)
+	}
+	state, err := test.Clients[learnerIdx].GetInternalState(test.Context, &emptypb.Empty{})
+	if err != nil || state.Status != syncinator.ServerStatus_LEARNER {
+		t.Fatalf("expected server %d to be a learner, got %v %v", learnerIdx, state, err)
+	}
+
+	// Two of three voters commit, the learner does not count
+	voterIdx := (leaderIdx + 1) % learnerIdx
+	test.Clients[voterIdx].Crash(test.Context, &emptypb.Empty{})
+	test.Clients[learnerIdx].Crash(test.Context, &emptypb.Empty{})
+	filemeta1 := &syncinator.FileMetaData{
+		Filename:      "testFile1",
+		Version:       1,
+		BlockHashList: []string{"hash1"},
+	}
+	if _, err := test.Clients[leaderIdx].UpdateFile(test.Context, filemeta1); err != nil {
+		t.Fatalf("update without learner failed: %v", err)
+	}
+
+	// The learner catches up in the background
+	test.Clients[voterIdx].Restore(test.Context, &emptypb.Empty{})
+	test.Clients[learnerIdx].Restore(test.Context, &emptypb.Empty{})
+	time.Sleep(500 * time.Millisecond)
+	goldenMeta := map[string]*syncinator.FileMetaData{filemeta1.Filename: filemeta1}
+	if _, err := CheckInternalState(nil, nil, nil, goldenMeta, test.Clients[learnerIdx], test.Context); err != nil {
+		t.Fatalf("learner: %v", err)
+	}
+
+	if _, err := test.Clients[leaderIdx].PromoteLearner(test.Context, &syncinator.RaftMember{Id: int64(learnerIdx)}); err != nil {
+		t.Fatalf("promote failed: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+	state, err = test.Clients[learnerIdx].GetInternalState(test.Context, &emptypb.Empty{})
+	if err != nil || state.Status != syncinator.ServerStatus_FOLLOWER {
+		t.Fatalf("expected server %d to be a follower after promotion, got %v %v", learnerIdx, state, err)
+	}
+
+	// Three of four voters are now needed
+	test.Clients[voterIdx].Crash(test.Context, &emptypb.Empty{})
+	test.Clients[learnerIdx].Crash(test.Context, &emptypb.Empty{})
+	filemeta1.Version = 2
+	if _, err := test.Clients[leaderIdx].UpdateFile(test.Context, filemeta1); err == nil {
+		t.Fatalf("expected update without majority of voters to fail")
+	}
+}
+
+func TestRaftRequestDeadline(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+	for idx := range test.Clients {
+		if idx != leaderIdx {
+			test.Clients[idx].Crash(test.Context, &emptypb.Empty{})
+		}
+	}
+
+	// Without a majority, requests give up at the client's deadline
+	filemeta1 := &syncinator.FileMetaData{
+		Filename:      "testFile1",
+		Version:       1,
+		BlockHashList: []string{"hash1"},
+	}
+	ctx, cancel := context.WithTimeout(test.Context, 300*time.Millisecond)
+	_, err := test.Clients[leaderIdx].UpdateFile(ctx, filemeta1)
+	cancel()
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected update to exceed its deadline, got %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(test.Context, 300*time.Millisecond)
+	_, err = test.Clients[leaderIdx].SendHeartbeat(ctx, &emptypb.Empty{})
+	cancel()
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected heartbeat to exceed its deadline, got %v", err)
+	}
+}
+
+func TestRaftUpdateFileOutcomeUnknown(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+
+	// The leader steps down while the update waits to commit
+	IsolateServer(test, leaderIdx)
+	filemeta1 := &syncinator.FileMetaData{
+		Filename:      "testFile1",
+		Version:       1,
+		BlockHashList: []string{"hash1"},
+	}
+	_, err := test.Clients[leaderIdx].UpdateFile(test.Context, filemeta1)
+	if status.Code(err) != codes.Unavailable || status.Convert(err).Message() != syncinator.ErrCommitUnknown.Error() {
+		t.Fatalf("expected an unknown outcome, got %v", err)
+	}
+}
+
+func TestRaftPausedLeaderIsReplaced(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	oldLeaderIdx, oldLeaderTerm := GetLeader(test)
+	if oldLeaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+
+	// The others elect a new leader while the old one is frozen
+	test.Clients[oldLeaderIdx].Pause(test.Context, &emptypb.Empty{})
+	time.Sleep(2 * time.Second)
+	newLeaderIdx, newLeaderTerm := GetLeader(test)
+	if newLeaderIdx == -1 || newLeaderIdx == oldLeaderIdx || newLeaderTerm <= oldLeaderTerm {
+		t.Fatalf("expected a new leader, got %d in term %d", newLeaderIdx, newLeaderTerm)
+	}
+
+	// An update reaching the old leader as it wakes up must not commit
+	filemeta1 := &syncinator.FileMetaData{
+		Filename:      "testFile1",
+		Version:       1,
+		BlockHashList: []string{"hash1"},
+	}
+	updateErr := make(chan error, 1)
+	go func() {
+		_, err := test.Clients[oldLeaderIdx].UpdateFile(test.Context, filemeta1)
+		updateErr <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	test.Clients[oldLeaderIdx].Resume(test.Context, &emptypb.Empty{})
+	if err := <-updateErr; err == nil {
+		t.Fatalf("update on the stale leader succeeded")
+	}
+
+	time.Sleep(time.Second)
+	numLeaders := 0
+	for _, server := range test.Clients {
+		state, _ := server.GetInternalState(test.Context, &emptypb.Empty{})
+		if state.Status == syncinator.ServerStatus_LEADER {
+			numLeaders++
+		}
+		if _, ok := state.MetaMap.FileInfoMap[filemeta1.Filename]; ok {
+			t.Fatalf("update on the stale leader was applied")
+		}
+	}
+	if numLeaders != 1 {
+		t.Fatalf("expected one leader after resuming, got %d", numLeaders)
+	}
+}
+
+func TestRaftOneWayPartition(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, leaderTerm := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+	followerIdx := (leaderIdx + 1) % len(test.Clients)
+
+	// The follower stops hearing from the leader, but still reaches it
+	test.Clients[leaderIdx].SetLinkFaults(test.Context, &syncinator.LinkFaults{
+		Links: []*syncinator.LinkFault{{ServerId: int64(followerIdx), IsPartitioned: true}},
+	})
+	UpdateFiles(test, leaderIdx, 5)
+	time.Sleep(2 * time.Second)
+
+	// Pre-vote keeps the cut off follower from deposing the leader
+	newLeaderIdx, newLeaderTerm := GetLeader(test)
+	if newLeaderIdx != leaderIdx || newLeaderTerm != leaderTerm {
+		t.Fatalf("leader %d of term %d was replaced by %d of term %d", leaderIdx, leaderTerm, newLeaderIdx, newLeaderTerm)
+	}
+	followerState, _ := test.Clients[followerIdx].GetInternalState(test.Context, &emptypb.Empty{})
+	if followerState.Term != leaderTerm {
+		t.Fatalf("cut off follower moved to term %d", followerState.Term)
+	}
+
+	// The follower catches up once the link heals
+	test.Clients[leaderIdx].SetLinkFaults(test.Context, &syncinator.LinkFaults{})
+	time.Sleep(500 * time.Millisecond)
+	leaderState, _ := test.Clients[leaderIdx].GetInternalState(test.Context, &emptypb.Empty{})
+	followerState, _ = test.Clients[followerIdx].GetInternalState(test.Context, &emptypb.Empty{})
+	if !SameLog(followerState.Log, leaderState.Log) {
+		t.Fatalf("follower did not catch up after the link healed")
+	}
+}
+
+func TestRaftSlowFollower(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, _ := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+	followerIdx := (leaderIdx + 1) % len(test.Clients)
+
+	// Messages to the follower are slow and lossy, the other one commits
+	test.Clients[leaderIdx].SetLinkFaults(test.Context, &syncinator.LinkFaults{
+		Links: []*syncinator.LinkFault{{ServerId: int64(followerIdx), DropRate: 0.3, DelayMillis: 50}},
+	})
+	for i := 0; i < 10; i++ {
+		filemeta := &syncinator.FileMetaData{
+			Filename:      fmt.Sprintf("testFile%d", i),
+			Version:       1,
+			BlockHashList: []string{fmt.Sprintf("hash%d", i)},
+		}
+		if _, err := test.Clients[leaderIdx].UpdateFile(test.Context, filemeta); err != nil {
+			t.Fatalf("update %d failed: %v", i, err)
+		}
+	}
+
+	test.Clients[leaderIdx].SetLinkFaults(test.Context, &syncinator.LinkFaults{})
+	time.Sleep(500 * time.Millisecond)
+	leaderState, _ := test.Clients[leaderIdx].GetInternalState(test.Context, &emptypb.Empty{})
+	followerState, _ := test.Clients[followerIdx].GetInternalState(test.Context, &emptypb.Empty{})
+	if !SameLog(followerState.Log, leaderState.Log) {
+		t.Fatalf("slow follower did not catch up")
+	}
+
+	// Client requests can be dropped too
+	test.Clients[leaderIdx].SetLinkFaults(test.Context, &syncinator.LinkFaults{
+		Clients: &syncinator.LinkFault{IsPartitioned: true},
+	})
+	_, err := test.Clients[leaderIdx].GetFileInfoMap(test.Context, &emptypb.Empty{})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected the client request to be dropped, got %v", err)
+	}
+
+	// A restored server starts over with healthy links
+	test.Clients[leaderIdx].Crash(test.Context, &emptypb.Empty{})
+	test.Clients[leaderIdx].Restore(test.Context, &emptypb.Empty{})
+	_, err = test.Clients[leaderIdx].GetFileInfoMap(test.Context, &emptypb.Empty{})
+	if status.Convert(err).Message() == syncinator.ErrMessageDropped.Error() {
+		t.Fatalf("client request dropped after restore")
+	}
+}
+
+func TestRaftInspection(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, leaderTerm := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+	followerIdx := (leaderIdx + 1) % len(test.Clients)
+
+	// The follower misses the updates
+	test.Clients[followerIdx].Crash(test.Context, &emptypb.Empty{})
+	UpdateFiles(test, leaderIdx, 5)
+	time.Sleep(200 * time.Millisecond)
+
+	leaderStatus, err := test.Clients[leaderIdx].GetRaftStatus(test.Context, &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("could not get the leader status: %v", err)
+	}
+	if leaderStatus.Status != syncinator.ServerStatus_LEADER || leaderStatus.Term != leaderTerm || leaderStatus.LeaderId != int64(leaderIdx) {
+		t.Fatalf("unexpected leader status %v", leaderStatus)
+	}
+	if leaderStatus.CommitIndex != leaderStatus.LastLogIndex || leaderStatus.LastApplied != leaderStatus.CommitIndex {
+		t.Fatalf("leader has not committed and applied its log: %v", leaderStatus)
+	}
+	for peerIdx := range test.Clients {
+		if peerIdx == leaderIdx {
+			continue
+		}
+		matchIndex, ok := leaderStatus.MatchIndex[int64(peerIdx)]
+		if !ok {
+			t.Fatalf("leader reports no matchIndex for %d", peerIdx)
+		}
+		if peerIdx == followerIdx && matchIndex >= leaderStatus.LastLogIndex {
+			t.Fatalf("crashed follower has matchIndex %d", matchIndex)
+		}
+		if peerIdx != followerIdx && matchIndex != leaderStatus.LastLogIndex {
+			t.Fatalf("follower %d has matchIndex %d, expected %d", peerIdx, matchIndex, leaderStatus.LastLogIndex)
+		}
+	}
+
+	// Crashed servers still report their state
+	followerStatus, err := test.Clients[followerIdx].GetRaftStatus(test.Context, &emptypb.Empty{})
+	if err != nil || followerStatus.Status != syncinator.ServerStatus_CRASHED || followerStatus.MatchIndex != nil {
+		t.Fatalf("unexpected crashed follower status %v: %v", followerStatus, err)
+	}
+
+	// Ranges are clamped to the log
+	leaderState, _ := test.Clients[leaderIdx].GetInternalState(test.Context, &emptypb.Empty{})
+	logEntries, err := test.Clients[leaderIdx].GetLogEntries(test.Context, &syncinator.LogRange{StartIndex: 1, EndIndex: 100})
+	if err != nil {
+		t.Fatalf("could not get log entries: %v", err)
+	}
+	if logEntries.StartIndex != 1 || !SameLog(logEntries.Entries, leaderState.Log[1:]) {
+		t.Fatalf("unexpected log entries from %d: %v", logEntries.StartIndex, logEntries.Entries)
+	}
+}
+
+func TestRaftSetLeaderKeepsLiveLeader(t *testing.T) {
+	cfgPath := "./config_files/3nodes.json"
+	test := InitTest(cfgPath)
+	defer EndTest(test)
+
+	leaderIdx, leaderTerm := GetLeader(test)
+	if leaderIdx == -1 {
+		t.Fatalf("no leader elected")
+	}
+	followerIdx := (leaderIdx + 1) % len(test.Clients)
+
+	// The pre-vote fails while the leader is alive, so no term is bumped
+	if _, err := test.Clients[followerIdx].SetLeader(test.Context, &emptypb.Empty{}); err == nil {
+		t.Fatalf("follower %d took over from a live leader", followerIdx)
+	}
+	newLeaderIdx, newLeaderTerm := GetLeader(test)
+	if newLeaderIdx != leaderIdx || newLeaderTerm != leaderTerm {
+		t.Fatalf("leader %d of term %d was replaced by %d of term %d", leaderIdx, leaderTerm, newLeaderIdx, newLeaderTerm)
+	}
+}
+
 func TestRaftSetLeader(t *testing.T) {
 	cfgPath := "./config_files/6nodes.json"
 	test := InitTest(cfgPath)
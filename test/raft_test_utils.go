@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
@@ -114,6 +115,84 @@ func InitRaftServers(cfgPath string, cfg syncinator.RaftConfig) []*exec.Cmd {
 	return cmdList
 }
 
+// JoinRaftServer starts a server outside of the configuration, listening on addr
+func JoinRaftServer(test *TestInfo, id int, addr string) syncinator.RaftSyncinatorClient {
+	cmd := exec.Command("_bin/SyncinatorRaftServerExec", "-f", test.CfgPath, "-i", strconv.Itoa(id), "-join", addr)
+	cmd.Stderr = os.Stderr
+	cmd.Stdout = os.Stdout
+	if err := cmd.Start(); err != nil {
+		log.Fatal("Error starting joining server ", err)
+	}
+	time.Sleep(time.Second)
+
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		log.Fatal("Error connecting to joining server ", err)
+	}
+	client := syncinator.NewRaftSyncinatorClient(conn)
+
+	test.Procs = append(test.Procs, cmd)
+	test.Conns = append(test.Conns, conn)
+	test.Clients = append(test.Clients, client)
+	return client
+}
+
+// IsolateServer cuts the server at idx off from all other servers in both directions
+func IsolateServer(test TestInfo, idx int) {
+	others := make([]int64, 0)
+	for otherIdx, server := range test.Clients {
+		if otherIdx != idx {
+			others = append(others, int64(otherIdx))
+			server.MakeServerUnreachableFrom(test.Context, &syncinator.UnreachableFromServers{ServerIds: []int64{int64(idx)}})
+		}
+	}
+	test.Clients[idx].MakeServerUnreachableFrom(test.Context, &syncinator.UnreachableFromServers{ServerIds: others})
+}
+
+// HealPartitions makes all servers reachable from each other again
+func HealPartitions(test TestInfo) {
+	for _, server := range test.Clients {
+		server.MakeServerUnreachableFrom(test.Context, &syncinator.UnreachableFromServers{ServerIds: []int64{}})
+	}
+}
+
+// UpdateFiles sends numFiles updates of distinct files from several clients at once
+func UpdateFiles(test TestInfo, serverIdx int, numFiles int) {
+	var wg sync.WaitGroup
+	for client := 0; client < 20; client++ {
+		wg.Add(1)
+		go func(client int) {
+			defer wg.Done()
+			for i := client; i < numFiles; i += 20 {
+				filemeta := &syncinator.FileMetaData{
+					Filename:      fmt.Sprintf("testFile%d", i),
+					Version:       1,
+					BlockHashList: []string{fmt.Sprintf("hash%d", i)},
+				}
+				test.Clients[serverIdx].UpdateFile(test.Context, filemeta)
+			}
+		}(client)
+	}
+	wg.Wait()
+}
+
+// GetLeader returns the id and term of the leader with the highest term, or -1 if there is none
+func GetLeader(test TestInfo) (int, int64) {
+	leaderIdx := -1
+	leaderTerm := int64(-1)
+	for idx, server := range test.Clients {
+		state, err := server.GetInternalState(test.Context, &emptypb.Empty{})
+		if err != nil || state == nil {
+			continue
+		}
+		if state.Status == syncinator.ServerStatus_LEADER && state.Term > leaderTerm {
+			leaderIdx = idx
+			leaderTerm = state.Term
+		}
+	}
+	return leaderIdx, leaderTerm
+}
+
 func CheckInternalState(isLeader *bool, term *int64, log []*syncinator.UpdateOperation, fileMetaMap map[string]*syncinator.FileMetaData, server syncinator.RaftSyncinatorClient, ctx context.Context) (bool, error) {
 	state, err := server.GetInternalState(ctx, &emptypb.Empty{})
 	if err != nil {